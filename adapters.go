@@ -0,0 +1,191 @@
+package funcmap
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"text/template"
+)
+
+// A function called with arguments of any type, the way expression evaluators such as govaluate
+// call them.
+type Func func(args ...interface{}) (interface{}, error)
+
+// The function `f`, registered as `name`, as a Func. The arguments are converted to the parameter
+// types where that loses nothing, such as the float64 3 to an int64, and nil is the zero value.
+// An error result, or a panic, is returned as the error.
+func Dynamic(name string, f interface{}) Func {
+	fv := reflect.ValueOf(f)
+	if fv.Kind() != reflect.Func {
+		return func(...interface{}) (interface{}, error) {
+			return nil, fmt.Errorf("%s is a %T, not a function", name, f)
+		}
+	}
+	t := fv.Type()
+	return func(args ...interface{}) (v interface{}, err error) {
+		in, err := arguments(t, args)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				v, err = nil, &PanicError{Name: name, Args: args, Value: r}
+			}
+		}()
+		out := fv.Call(in)
+		if n := len(out); n != 0 && t.Out(n-1) == errorType {
+			if !out[n-1].IsNil() {
+				return nil, out[n-1].Interface().(error)
+			}
+			out = out[:n-1]
+		}
+		if len(out) == 0 {
+			return nil, nil
+		}
+		return out[0].Interface(), nil
+	}
+}
+
+// Each function of `funcs` as a Func.
+func Dynamics(funcs template.FuncMap) map[string]Func {
+	dynamics := make(map[string]Func, len(funcs))
+	for name, f := range funcs {
+		dynamics[name] = Dynamic(name, f)
+	}
+	return dynamics
+}
+
+// Add each function of `funcs` through `add`, in name order, for engines with a registry, such as
+// Jet's Set.AddGlobal, that call Go functions as they are.
+func Register(funcs template.FuncMap, add func(name string, f interface{})) {
+	names := make([]string, 0, len(funcs))
+	for name := range funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(name, funcs[name])
+	}
+}
+
+// A registry of globals, such as Jet's *Set, whose AddGlobal returns the registry.
+type Globals[S any] interface {
+	AddGlobal(name string, v interface{}) S
+}
+
+// Add each function of `funcs` to `set`, in name order, as in
+// `AddGlobals(jet.NewSet(loader), funcs)`. Jet converts the arguments of a call to the parameter
+// types, as templates do.
+func AddGlobals[S Globals[S]](set S, funcs template.FuncMap) S {
+	Register(funcs, func(name string, f interface{}) { set.AddGlobal(name, f) })
+	return set
+}
+
+// The functions of `funcs` and the entries of `data` in one map, for expression evaluators, such
+// as expr, that take them as their environment. The data hides functions of the same name.
+func Env(funcs template.FuncMap, data map[string]interface{}) map[string]interface{} {
+	env := make(map[string]interface{}, len(funcs)+len(data))
+	for name, f := range funcs {
+		env[name] = f
+	}
+	for k, v := range data {
+		env[k] = v
+	}
+	return env
+}
+
+// The value, rather than the text, of a template pipeline, such as `.name | upper`, with `funcs`
+// and `data`.
+func Eval(funcs template.FuncMap, expression string, data interface{}) (interface{}, error) {
+	var v interface{}
+	result := template.FuncMap{"eval_result": func(x interface{}) string {
+		v = x
+		return ""
+	}}
+	tmpl, err := template.New("eval").Funcs(funcs).Funcs(result).Parse("{{ eval_result (" + expression + ") }}")
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(ioutil.Discard, data); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// The arguments of a call to a function of type `t`.
+func arguments(t reflect.Type, args []interface{}) ([]reflect.Value, error) {
+	n := t.NumIn()
+	if t.IsVariadic() && len(args) < n-1 {
+		return nil, fmt.Errorf("wants at least %s, not %d", plural(n-1, "argument"), len(args))
+	}
+	if !t.IsVariadic() && len(args) != n {
+		return nil, fmt.Errorf("wants %s, not %d", plural(n, "argument"), len(args))
+	}
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		var pt reflect.Type
+		if t.IsVariadic() && i >= n-1 {
+			pt = t.In(n - 1).Elem()
+		} else {
+			pt = t.In(i)
+		}
+		v, err := convert(a, pt)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %v", i+1, err)
+		}
+		in[i] = v
+	}
+	return in, nil
+}
+
+// `a` as a `t`, if it is one or converts to one without loss.
+func convert(a interface{}, t reflect.Type) (reflect.Value, error) {
+	if a == nil {
+		return reflect.Zero(t), nil
+	}
+	v := reflect.ValueOf(a)
+	switch {
+	case v.Type().AssignableTo(t):
+		return v, nil
+	case number(v.Kind()) && number(t.Kind()):
+		c := v.Convert(t)
+		if c.Convert(v.Type()).Interface() != a || negative(c) != negative(v) {
+			return reflect.Value{}, fmt.Errorf("%v does not fit a %s", a, t)
+		}
+		return c, nil
+	case v.Kind() == reflect.Slice && t.Kind() == reflect.Slice:
+		s := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			e, err := convert(v.Index(i).Interface(), t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			s.Index(i).Set(e)
+		}
+		return s, nil
+	}
+	return reflect.Value{}, fmt.Errorf("a %T is not a %s", a, t)
+}
+
+// `n` and `word`, with an `s` unless there is one.
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
+
+func number(k reflect.Kind) bool {
+	return reflect.Int <= k && k <= reflect.Float64
+}
+
+func negative(v reflect.Value) bool {
+	switch {
+	case reflect.Int <= v.Kind() && v.Kind() <= reflect.Int64:
+		return v.Int() < 0
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package funcmap
+
+import (
+	"errors"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestDynamic(t *testing.T) {
+	funcs := Dynamics(New(WithV4Map()))
+	tests := []struct {
+		name string
+		args []interface{}
+		want interface{}
+		err  string
+	}{
+		// Evaluators such as govaluate pass every number as a float64.
+		{name: "add", args: []interface{}{2.0, 3.0}, want: int64(5)},
+		{name: "add", args: []interface{}{2.5, 3.0}, err: "add: argument 1: 2.5 does not fit a int64"},
+		{name: "ip4_inc", args: []interface{}{3.0, -1.0, "10.0.0.1"}, want: "10.0.0.0"},
+		{name: "ip4_inc", args: []interface{}{-1.0, 1.0, "10.0.0.1"}, err: "ip4_inc: argument 1: -1 does not fit a uint8"},
+		{name: "ip4_inc", args: []interface{}{300, 1, "10.0.0.1"}, err: "ip4_inc: argument 1: 300 does not fit a uint8"},
+		{name: "join", args: []interface{}{",", []interface{}{"a", "b"}}, want: "a,b"},
+		{name: "join", args: []interface{}{",", []interface{}{"a", 1}}, err: "join: argument 2: a int is not a string"},
+		{name: "upper", args: []interface{}{nil}, want: ""},
+		{name: "upper", args: nil, err: "upper: wants 1 argument, not 0"},
+		{name: "add", args: nil, err: "add: wants 2 arguments, not 0"},
+		{name: "from_json", args: []interface{}{"{"}, err: "unexpected end of JSON input"},
+		{name: "substr", args: []interface{}{1, 2, "abc"}, want: "b"},
+	}
+	for _, test := range tests {
+		got, err := funcs[test.name](test.args...)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.name)
+			continue
+		}
+		if assert.NoError(t, err, test.name) {
+			assert.Equal(t, test.want, got, test.name)
+		}
+	}
+
+	variadic := Dynamic("debug", Debug)
+	got, err := variadic("a", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "string a int 1", got)
+
+	fail := errors.New("fail")
+	got, err = Dynamic("fail", func() error { return fail })()
+	assert.Nil(t, got)
+	assert.Equal(t, fail, err)
+	got, err = Dynamic("ok", func() error { return nil })()
+	assert.Nil(t, got)
+	assert.NoError(t, err)
+
+	_, err = Dynamic("boom", func() string { panic("boom") })()
+	var p *PanicError
+	assert.True(t, errors.As(err, &p))
+	_, err = Dynamic("x", 1)()
+	assert.EqualError(t, err, "x is a int, not a function")
+}
+
+//
+func TestRegister(t *testing.T) {
+	// A registry, such as Jet's, that takes functions one at a time.
+	names := []string{}
+	Register(template.FuncMap{"b": Rand, "a": Rand}, func(name string, f interface{}) {
+		names = append(names, name)
+	})
+	assert.Equal(t, []string{"a", "b"}, names)
+}
+
+// Shaped like Jet's *Set.
+type jetSet struct {
+	globals map[string]interface{}
+}
+
+func (s *jetSet) AddGlobal(name string, v interface{}) *jetSet {
+	s.globals[name] = v
+	return s
+}
+
+//
+func TestAddGlobals(t *testing.T) {
+	set := AddGlobals(&jetSet{globals: map[string]interface{}{}}, New(WithV4Map()))
+	assert.Len(t, set.globals, len(New(WithV4Map())))
+	upper, ok := set.globals["upper"].(func(string) string)
+	if assert.True(t, ok) {
+		assert.Equal(t, "A", upper("a"))
+	}
+}
+
+//
+func TestEnv(t *testing.T) {
+	env := Env(New(WithV4Map()), map[string]interface{}{"name": "api", "upper": "data"})
+	assert.Equal(t, "api", env["name"])
+	assert.Equal(t, "data", env["upper"])
+	assert.NotNil(t, env["lower"])
+}
+
+//
+func TestEval(t *testing.T) {
+	funcs := New(WithV4Map())
+	got, err := Eval(funcs, `.hosts | split ","`, map[string]interface{}{"hosts": "a,b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, got)
+
+	got, err = Eval(funcs, `add 1 2`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), got)
+
+	_, err = Eval(funcs, `nope`, nil)
+	assert.EqualError(t, err, `template: eval:1: function "nope" not defined`)
+}
@@ -0,0 +1,29 @@
+//go:build !race
+
+package funcmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The allocations of the fast paths, at most.
+func TestAllocs(t *testing.T) {
+	s := []string{"a", "b", "c"}
+	tests := []struct {
+		name   string
+		f      func()
+		allocs float64
+	}{
+		{name: "Cleanser", f: func() { Cleanser(`[^[:alnum:]]`, "a-b_c d") }, allocs: 3},
+		{name: "IPMath", f: func() { IPMath("_._._.[+1]", "10.0.0.1") }, allocs: 1},
+		{name: "IPMath v6", f: func() { IPMath("_:_:_:_:_:_:_:[+1]", "fe80:0:0:0:0:0:0:1") }, allocs: 1},
+		{name: "Debug", f: func() { Debug("a", 1, []string{"b"}) }, allocs: 5},
+		{name: "Join", f: func() { Join(",", s) }, allocs: 1},
+	}
+	for _, test := range tests {
+		got := testing.AllocsPerRun(100, test.f)
+		assert.True(t, got <= test.allocs, "%s allocates %v times, more than %v", test.name, got, test.allocs)
+	}
+}
@@ -0,0 +1,236 @@
+package funcmap
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"text/template"
+	"text/template/parse"
+)
+
+// A problem with a function call in a template. Kind is `unknown`, `arity`, `type`, `deprecated`
+// or `changed`.
+type Finding struct {
+	Location string
+	Name     string
+	Kind     string
+	Message  string
+}
+
+//
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s %s", f.Location, f.Name, f.Message)
+}
+
+// Checks templates against the functions they will be executed with, before they are executed.
+type Analyzer struct {
+	// The functions the templates will be executed with.
+	Funcs template.FuncMap
+	// Names to report, and what to use instead of them. See Deprecated.
+	Deprecated map[string]string
+	// If set, also report calls to functions that are missing from or differ in Target. See Lint.
+	Target template.FuncMap
+}
+
+// The Go-style aliases kept for compatibility, and the names to use instead. The names that v4
+// changes, such as `trim`, are not deprecated; an Analyzer with a Target reports them as changed.
+var Deprecated = map[string]string{
+	"IPMath":   "ip_math",
+	"IP4Inc":   "ip4_inc",
+	"IP4Next":  "ip4_next",
+	"IP4Prev":  "ip4_prev",
+	"IP4Add":   "ip4_add",
+	"IP4Join":  "ip4_join",
+	"IP6Inc":   "ip6_inc",
+	"IP6Next":  "ip6_next",
+	"IP6Prev":  "ip6_prev",
+	"IP6Add":   "ip6_add",
+	"IP6Join":  "ip6_join",
+	"CIDRNext": "cidr_next",
+	"IPInts":   "ip_ints",
+	"IPSplit":  "ip_split",
+	"ToInt":    "to_int",
+	"DecToInt": "dec_to_int",
+	"HexToInt": "hex_to_int",
+	"FromInt":  "from_int",
+}
+
+// Report unknown functions, calls with the wrong number of arguments, literal arguments of the
+// wrong type, deprecated names and, with a Target, changed functions in the template `text`.
+// Only syntax errors are returned as errors.
+func (a Analyzer) Analyze(name, text string) ([]Finding, error) {
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+	if _, err := tree.Parse(text, "", "", trees); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(trees))
+	for n := range trees {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	findings := []Finding{}
+	for _, n := range names {
+		t := trees[n]
+		report := func(node parse.Node, name, kind, format string, args ...interface{}) {
+			location, _ := t.ErrorContext(node)
+			findings = append(findings, Finding{Location: location, Name: name, Kind: kind, Message: fmt.Sprintf(format, args...)})
+		}
+		walk(t.Root, func(node parse.Node) {
+			switch node := node.(type) {
+			case *parse.PipeNode:
+				for i, c := range node.Cmds {
+					if ident, ok := c.Args[0].(*parse.IdentifierNode); ok {
+						a.call(ident, c.Args[1:], i != 0, report)
+					}
+				}
+			case *parse.CommandNode:
+				for _, arg := range node.Args[1:] {
+					if ident, ok := arg.(*parse.IdentifierNode); ok {
+						a.call(ident, nil, false, report)
+					}
+				}
+			}
+		})
+	}
+	return findings, nil
+}
+
+type reporter func(node parse.Node, name, kind, format string, args ...interface{})
+
+// Check a call to `ident` with `args` and, if `piped`, the result of the previous command.
+func (a Analyzer) call(ident *parse.IdentifierNode, args []parse.Node, piped bool, report reporter) {
+	name := ident.Ident
+	f, ok := a.Funcs[name]
+	if !ok {
+		if _, builtin := builtins[name]; builtin {
+			return
+		}
+		if suggestion := a.closest(name); suggestion != "" {
+			report(ident, name, "unknown", "is not defined; did you mean %s?", suggestion)
+		} else {
+			report(ident, name, "unknown", "is not defined")
+		}
+		return
+	}
+	if use, deprecated := a.Deprecated[name]; deprecated {
+		report(ident, name, "deprecated", "is deprecated; use %s", use)
+	}
+	if a.Target != nil {
+		if reason := migration(f, a.Target[name]); reason != "" {
+			report(ident, name, "changed", "%s", reason)
+		}
+	}
+
+	ft := reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return
+	}
+	n := len(args)
+	if piped {
+		n++
+	}
+	if want := ft.NumIn(); ft.IsVariadic() && n < want-1 {
+		report(ident, name, "arity", "takes at least %s, not %d", plural(want-1, "argument"), n)
+		return
+	} else if !ft.IsVariadic() && n != want {
+		report(ident, name, "arity", "takes %s, not %d", plural(want, "argument"), n)
+		return
+	}
+	for i, arg := range args {
+		var in reflect.Type
+		if ft.IsVariadic() && i >= ft.NumIn()-1 {
+			in = ft.In(ft.NumIn() - 1).Elem()
+		} else {
+			in = ft.In(i)
+		}
+		if literal, ok := assignable(arg, in); !ok {
+			report(arg, name, "type", "argument %d is %s, not %s", i+1, literal, in)
+		}
+	}
+}
+
+// Whether the literal `arg` can be passed as `in`, and a description of the literal.
+func assignable(arg parse.Node, in reflect.Type) (string, bool) {
+	if in.Kind() == reflect.Interface && in.NumMethod() == 0 {
+		return "", true
+	}
+	switch arg := arg.(type) {
+	case *parse.StringNode:
+		return "a string", reflect.TypeOf("").AssignableTo(in) || in.Kind() == reflect.String
+	case *parse.BoolNode:
+		return "a bool", in.Kind() == reflect.Bool
+	case *parse.NilNode:
+		switch in.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return "nil", true
+		}
+		return "nil", false
+	case *parse.NumberNode:
+		switch in.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return "a non-integer number", arg.IsInt
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return "a non-natural number", arg.IsUint
+		case reflect.Float32, reflect.Float64:
+			return "a number", arg.IsFloat
+		case reflect.Complex64, reflect.Complex128:
+			return "a number", arg.IsComplex
+		}
+		return "a number", false
+	}
+	return "", true
+}
+
+// The defined function whose name is closest to `name`, if it is a likely typo.
+func (a Analyzer) closest(name string) string {
+	best, distance := "", 3
+	for n := range a.Funcs {
+		if d := levenshtein(name, n); d < distance || d == distance && n < best {
+			best, distance = n, d
+		}
+	}
+	if distance > 2 {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// The functions that text/template always defines.
+var builtins = map[string]struct{}{
+	"and": {}, "call": {}, "html": {}, "index": {}, "slice": {}, "js": {}, "len": {}, "not": {},
+	"or": {}, "print": {}, "printf": {}, "println": {}, "urlquery": {},
+	"eq": {}, "ge": {}, "gt": {}, "le": {}, "lt": {}, "ne": {},
+}
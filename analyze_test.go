@@ -0,0 +1,47 @@
+package funcmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestAnalyze(t *testing.T) {
+	text := `{{ define "d" }}{{ IPMath "_.[+1]" . }}{{ end }}
+{{- uper .name }} {{ add "1" 2 }} {{ .n | add 1 }} {{ substr 1 }}
+{{ if eq (len .list) 0 }}{{ printf "%d" (mul 2.5 2) }}{{ end }}
+{{ range .list }}{{ path_join "a" . | base64_encode }}{{ dump nil 1 }}{{ end }}
+{{ trim . "x" }} {{ print upper }}`
+
+	got, err := Analyzer{Funcs: New(WithV1Map()), Deprecated: Deprecated, Target: New(WithV4Map())}.Analyze("t", text)
+	assert.NoError(t, err)
+	want := []string{
+		`t:1:19: IPMath is deprecated; use ip_math`,
+		`t:2:4: uper is not defined; did you mean upper?`,
+		`t:2:25: add argument 1 is a string, not int64`,
+		`t:2:54: substr takes 3 arguments, not 1`,
+		`t:3:45: mul argument 1 is a non-integer number, not int64`,
+		`t:4:38: base64_encode is not defined`,
+		`t:5:3: trim is a different func(string, string) string; check the order of its arguments`,
+		`t:5:26: upper takes 1 argument, not 0`,
+	}
+	ss := []string{}
+	for _, f := range got {
+		ss = append(ss, f.String())
+	}
+	assert.Equal(t, want, ss)
+	assert.Equal(t, "deprecated", got[0].Kind)
+	assert.Equal(t, "arity", got[3].Kind)
+
+	got, err = Analyzer{Funcs: New(WithV1Map(), WithCryptoMap())}.Analyze("t", `{{ .x | base64 }}{{ IPMath "_" "1.2.3.4" }}`)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = Analyzer{Funcs: New(WithV4Map()), Deprecated: Deprecated}.Analyze("t", `{{ "__a" | trim "_" }}{{ .s | replace -1 "a" "b" }}`)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = Analyzer{}.Analyze("t", `{{ if }}`)
+	assert.Error(t, err)
+}
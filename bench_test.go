@@ -0,0 +1,83 @@
+package funcmap
+
+import (
+	"io/ioutil"
+	"sort"
+	"testing"
+	"text/template"
+
+	"github.com/gomatic/clock"
+)
+
+// Every v1 function, by its documented example, other than those that sleep or change every map.
+// Aliases run the example of the name they are documented under, once.
+func BenchmarkV1(b *testing.B) {
+	funcs := New(WithV1Map(), WithSeed(1), WithClock(clock.Playground.MustTime()))
+	documented := map[string]bool{}
+	for name := range v1Map {
+		d, ok := Describe(v1Map, name)
+		if !ok || d.Example == "" {
+			b.Fatalf("%s has no example to benchmark", name)
+		}
+		documented[d.Name] = d.Name != "pause" && d.Name != "debug_toggle"
+	}
+	names := []string{}
+	for name, ok := range documented {
+		if ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tmpl := template.Must(template.New(name).Funcs(funcs).Parse(docs[name].Example))
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := tmpl.Execute(ioutil.Discard, exampleData); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+//
+func BenchmarkCleanser(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Cleanser(`[^[:alnum:]]`, "a-b_c d")
+	}
+}
+
+//
+func BenchmarkIPMath(b *testing.B) {
+	for _, c := range [][2]string{
+		{"_._._.[+1]", "10.0.0.1"},
+		{"[+1].[*2,%10].[-1].[R]", "10.0.0.1"},
+		{"_:_:_:_:_:_:_:[+1]", "fe80:0:0:0:0:0:0:1"},
+	} {
+		b.Run(c[0], func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				IPMath(c[0], c[1])
+			}
+		})
+	}
+}
+
+//
+func BenchmarkDebug(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Debug("a", 1, []string{"b"})
+	}
+}
+
+//
+func BenchmarkJoin(b *testing.B) {
+	s := []string{"a", "b", "c"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Join(",", s)
+	}
+}
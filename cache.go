@@ -0,0 +1,43 @@
+package funcmap
+
+import (
+	"regexp"
+	"sync"
+)
+
+// A concurrent map of values that are costly to make, such as compiled patterns. Once it holds
+// `size` values, new ones are made on every call but not kept, so that templates that build keys
+// from their data cannot grow it without bound.
+type cache struct {
+	lock   sync.RWMutex
+	values map[string]interface{}
+	size   int
+}
+
+func newCache(size int) *cache {
+	return &cache{values: map[string]interface{}{}, size: size}
+}
+
+// The value of `key`, made by `build` if it is not cached.
+func (c *cache) get(key string, build func() interface{}) interface{} {
+	c.lock.RLock()
+	v, ok := c.values[key]
+	c.lock.RUnlock()
+	if ok {
+		return v
+	}
+	v = build()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.values) < c.size {
+		c.values[key] = v
+	}
+	return v
+}
+
+var patterns = newCache(256)
+
+// The compiled `pattern`, which panics, as regexp.MustCompile does, if it does not compile.
+func compiled(pattern string) *regexp.Regexp {
+	return patterns.get(pattern, func() interface{} { return regexp.MustCompile(pattern) }).(*regexp.Regexp)
+}
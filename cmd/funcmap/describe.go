@@ -0,0 +1,159 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/gomatic/funcmap"
+)
+
+// Print each function of a map version, with its aliases, signature and summary.
+func list(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("funcmap list", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	version := mapFlag(flags)
+	if code, ok := parse(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() != 0 {
+		fmt.Fprintf(stderr, "usage: funcmap list [-map version]\n")
+		return 2
+	}
+
+	funcs := version.funcs()
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	for _, names := range groups(funcs) {
+		d, _ := funcmap.Describe(funcs, names[0])
+		fmt.Fprintf(w, "%s\t%s\t%s\n", strings.Join(names, ", "), reflect.TypeOf(funcs[names[0]]), d.Summary)
+	}
+	w.Flush()
+	return 0
+}
+
+// Print the documentation of the named functions of a map version.
+func doc(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("funcmap doc", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	version := mapFlag(flags)
+	if code, ok := parse(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() == 0 {
+		fmt.Fprintf(stderr, "usage: funcmap doc [-map version] name ...\n")
+		return 2
+	}
+
+	funcs := version.funcs()
+	code := 0
+	for i, name := range flags.Args() {
+		f, ok := funcs[name]
+		if !ok {
+			fmt.Fprintf(stderr, "funcmap: %s has no function %q\n", *version, name)
+			code = 1
+			continue
+		}
+		if i != 0 {
+			fmt.Fprintln(stdout)
+		}
+		fmt.Fprint(stdout, name)
+		if aliases := funcmap.Aliases(funcs, name); len(aliases) != 0 {
+			fmt.Fprintf(stdout, " (%s)", strings.Join(aliases, ", "))
+		}
+		fmt.Fprintf(stdout, "\n    %s\n", reflect.TypeOf(f))
+		d, ok := funcmap.Describe(funcs, name)
+		if !ok {
+			continue
+		}
+		if d.Name != name && !contains(funcmap.Aliases(funcs, name), d.Name) {
+			fmt.Fprintf(stdout, "\n    Like %s:\n", d.Name)
+		}
+		fmt.Fprintf(stdout, "\n    %s\n", d.Summary)
+		if d.Example != "" {
+			fmt.Fprintf(stdout, "\n    %s\n", d.Example)
+			if d.Output != "" {
+				fmt.Fprintf(stdout, "    %s\n", strings.Replace(d.Output, "\n", "\n    ", -1))
+			}
+		}
+	}
+	return code
+}
+
+// Print the functions added (+), removed (-) or changed (~) from one map version to another.
+func diff(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("funcmap diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	if code, ok := parse(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() != 2 {
+		fmt.Fprintf(stderr, "usage: funcmap diff version version\n")
+		return 2
+	}
+	var from, to mapVersion
+	for i, v := range []*mapVersion{&from, &to} {
+		if err := v.Set(flags.Arg(i)); err != nil {
+			fmt.Fprintf(stderr, "funcmap: %v\n", err)
+			return 2
+		}
+	}
+
+	before, after := from.funcs(), to.funcs()
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	for _, c := range funcmap.Compare(before, after) {
+		switch c.Kind {
+		case "added":
+			fmt.Fprintf(w, "+ %s\t%s\n", c.Name, reflect.TypeOf(after[c.Name]))
+		case "removed":
+			fmt.Fprintf(w, "- %s\t%s\n", c.Name, reflect.TypeOf(before[c.Name]))
+		default:
+			fmt.Fprintf(w, "~ %s\t%s\n", c.Name, c.Reason)
+		}
+	}
+	w.Flush()
+	return 0
+}
+
+// The names of `funcs` grouped with their aliases, lowercase names first, sorted.
+func groups(funcs template.FuncMap) [][]string {
+	names := make([]string, 0, len(funcs))
+	for n := range funcs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	grouped := map[string]bool{}
+	groups := [][]string{}
+	for _, n := range names {
+		if grouped[n] {
+			continue
+		}
+		group := append([]string{n}, funcmap.Aliases(funcs, n)...)
+		sort.Slice(group, func(i, j int) bool {
+			if li, lj := group[i] == strings.ToLower(group[i]), group[j] == strings.ToLower(group[j]); li != lj {
+				return li
+			}
+			return group[i] < group[j]
+		})
+		for _, g := range group {
+			grouped[g] = true
+		}
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	return groups
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
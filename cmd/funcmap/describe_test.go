@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestList(t *testing.T) {
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	assert.Equal(t, 0, run([]string{"list", "-map", "safe"}, nil, &stdout, &stderr), stderr.String())
+	assert.Regexp(t, `\ncidr_next, CIDRNext +func\(uint8, int8, int8, int8, \[\]int64\) \[\]int64 +Reserved; returns the address unchanged.\n`, stdout.String())
+	assert.Contains(t, stdout.String(), "\nto_json, toJSON ")
+	assert.NotContains(t, stdout.String(), "\nenv ")
+
+	assert.Equal(t, 2, run([]string{"list", "extra"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"list", "-map", "v0"}, nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), `unknown map version "v0"`)
+}
+
+//
+func TestDoc(t *testing.T) {
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	assert.Equal(t, 0, run([]string{"doc", "-map", "v4", "toJSON", "trim"}, nil, &stdout, &stderr), stderr.String())
+	assert.Equal(t, `toJSON (to_json)
+    func(interface {}) (string, error)
+
+    Compact JSON.
+
+    {{ to_json (split "," "a,b") }}
+    ["a","b"]
+
+trim
+    func(string, string) string
+
+    Like trim_:
+
+    Remove the characters of a cutset from both ends.
+
+    {{ "__a__" | trim_ "_" }}
+    a
+`, stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 1, run([]string{"doc", "-map", "v2", "camelcase", "nope"}, nil, &stdout, &stderr))
+	assert.Equal(t, "camelcase\n    func(string) string\n\n    A sprig function; see https://masterminds.github.io/sprig/.\n", stdout.String())
+	assert.Contains(t, stderr.String(), `v2 has no function "nope"`)
+
+	assert.Equal(t, 2, run([]string{"doc"}, nil, &stdout, &stderr))
+}
+
+//
+func TestDiff(t *testing.T) {
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	assert.Equal(t, 0, run([]string{"diff", "v1", "v4"}, nil, &stdout, &stderr), stderr.String())
+	assert.Equal(t, `~ basename    changed from func(string, ...string) string to func(string, string) string
+~ replace     changed from func(string, string, string, int) string to func(int, string, string, string) string
+~ trim        is a different func(string, string) string; check the order of its arguments
+~ trimLeft    is a different func(string, string) string; check the order of its arguments
+~ trimRight   is a different func(string, string) string; check the order of its arguments
+~ trim_left   is a different func(string, string) string; check the order of its arguments
+~ trim_right  is a different func(string, string) string; check the order of its arguments
+`, stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 0, run([]string{"diff", "v4", "safe"}, nil, &stdout, &stderr), stderr.String())
+	assert.Regexp(t, `\n- env_default +func\(string, string\) string\n`, stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 0, run([]string{"diff", "v2", "v3"}, nil, &stdout, &stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "~ sub ")
+
+	assert.Equal(t, 2, run([]string{"diff", "v1"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"diff", "v1", "v7"}, nil, &stdout, &stderr))
+}
@@ -0,0 +1,408 @@
+// Command funcmap renders Go templates with the funcmap functions.
+//
+//	funcmap [flags] [template file or directory ...]
+//
+// Templates are read from the files, the files below the directories, or stdin. All of them are
+// parsed into one set, so that they can `define` and `template` each other, and the first one, or
+// the one named by -template, is executed. Data is read from JSON, YAML, TOML or env files, by
+// extension, and merged in order. With -watch, it renders again whenever one of those files
+// changes, until it is interrupted.
+//
+// It also describes the functions of a map version:
+//
+//	funcmap list [-map version]
+//	funcmap doc [-map version] name ...
+//	funcmap diff version version
+//
+// And it evaluates expressions and templates interactively, with tab completion of the functions:
+//
+//	funcmap repl [-map version] [-data file ...] [-seed seed] [-clock time]
+//
+// To render a template file named like a command, use a path such as `./list`.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gomatic/clock"
+	"github.com/gomatic/funcmap"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+var versions = map[string]func() funcmap.Optional{
+	"v1":   funcmap.WithV1Map,
+	"v2":   funcmap.WithV2Map,
+	"v3":   funcmap.WithV3Map,
+	"v4":   funcmap.WithV4Map,
+	"safe": funcmap.WithSafeMap,
+}
+
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"list": list,
+	"doc":  doc,
+	"diff": diff,
+	"repl": replCommand,
+}
+
+// Closed to stop -watch, which otherwise runs until it is interrupted.
+var stopWatching <-chan struct{}
+
+type files []string
+
+func (f *files) String() string     { return strings.Join(*f, ",") }
+func (f *files) Set(s string) error { *f = append(*f, s); return nil }
+
+// Run the command in `args`, or render, and return the exit code: 0 on success, 1 on errors,
+// and 2 on bad usage.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) != 0 {
+		if c, ok := commands[args[0]]; ok {
+			return c(args[1:], stdin, stdout, stderr)
+		}
+	}
+	flags := flag.NewFlagSet("funcmap", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setup := setupFlags(flags)
+	strict := flags.Bool("strict", false, "fail on missing map keys")
+	output := flags.String("o", "", "write to `file` instead of stdout")
+	name := flags.String("template", "", "the `name` of the template to execute")
+	watching := flags.Bool("watch", false, "render again whenever a template or data file changes")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often -watch looks for changes")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: funcmap [flags] [template file or directory ...]\n")
+		flags.PrintDefaults()
+	}
+	if code, ok := parse(flags, args); !ok {
+		return code
+	}
+
+	funcs, err := setup.funcs()
+	if err != nil {
+		fmt.Fprintf(stderr, "funcmap: %v\n", err)
+		return 2
+	}
+	if *watching {
+		if flags.NArg() == 0 || contains(flags.Args(), "-") || contains(setup.data, "-") {
+			fmt.Fprintf(stderr, "funcmap: -watch needs template and data files, not stdin\n")
+			return 2
+		}
+		watch(append(flags.Args(), setup.data...), *output, *interval, stopWatching, func() error {
+			// Fresh functions, so that sequences and `started` are those of a fresh run.
+			funcs, err := setup.funcs()
+			if err != nil {
+				return err
+			}
+			return render(funcs, *strict, *name, flags.Args(), setup.data, *output, nil, stdout)
+		}, stderr)
+		return 0
+	}
+	if (flags.NArg() == 0 || contains(flags.Args(), "-")) && contains(setup.data, "-") {
+		fmt.Fprintf(stderr, "funcmap: the templates and the data cannot both come from stdin\n")
+		return 2
+	}
+	if err := render(funcs, *strict, *name, flags.Args(), setup.data, *output, stdin, stdout); err != nil {
+		fmt.Fprintf(stderr, "funcmap: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// The flags that choose the functions and the data.
+type setup struct {
+	version *mapVersion
+	data    files
+	seed    optionalInt64
+	clock   string
+}
+
+//
+func setupFlags(flags *flag.FlagSet) *setup {
+	s := &setup{version: mapFlag(flags)}
+	flags.Var(&s.data, "data", "a JSON, YAML, TOML or env `file` of data, or - for stdin; repeatable")
+	flags.Var(&s.seed, "seed", "make rand and the random IDs reproducible with `seed`")
+	flags.StringVar(&s.clock, "clock", "", "a fixed `time` for now, in RFC 3339")
+	return s
+}
+
+// The functions of the chosen map version with the seed and clock, and sequences of their own.
+func (s *setup) funcs() (template.FuncMap, error) {
+	options := []funcmap.Optional{funcmap.WithSequencers()}
+	if s.seed.v != nil {
+		options = append(options, funcmap.WithSeed(*s.seed.v))
+	}
+	if s.clock != "" {
+		t, err := parseTime(s.clock)
+		if err != nil {
+			return nil, fmt.Errorf("-clock: %v", err)
+		}
+		options = append(options, funcmap.WithClock(func() time.Time { return t }))
+	}
+	return s.version.funcs(options...), nil
+}
+
+// An int64 flag that records whether it was set.
+type optionalInt64 struct{ v *int64 }
+
+func (o *optionalInt64) String() string {
+	if o.v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*o.v, 10)
+}
+
+func (o *optionalInt64) Set(s string) error {
+	v, err := strconv.ParseInt(s, 0, 64)
+	o.v = &v
+	return err
+}
+
+// A map version flag.
+type mapVersion string
+
+func (v *mapVersion) String() string { return string(*v) }
+
+func (v *mapVersion) Set(s string) error {
+	if _, ok := versions[s]; !ok {
+		return fmt.Errorf("unknown map version %q", s)
+	}
+	*v = mapVersion(s)
+	return nil
+}
+
+// The functions of the map version.
+func (v mapVersion) funcs(options ...funcmap.Optional) template.FuncMap {
+	return funcmap.New(append([]funcmap.Optional{versions[string(v)]()}, options...)...)
+}
+
+// A -map flag on `flags`.
+func mapFlag(flags *flag.FlagSet) *mapVersion {
+	v := mapVersion("v1")
+	flags.Var(&v, "map", "the function map `version`: v1, v2, v3, v4 or safe")
+	return &v
+}
+
+// Parse `args` into `flags`, returning false and the exit code if the command should stop.
+func parse(flags *flag.FlagSet, args []string) (int, bool) {
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return 0, false
+	} else if err != nil {
+		return 2, false
+	}
+	return 0, true
+}
+
+func render(funcs template.FuncMap, strict bool, name string, paths, data []string, output string, stdin io.Reader, stdout io.Writer) error {
+	tmpl := template.New("").Funcs(funcs)
+	if strict {
+		tmpl.Option("missingkey=error")
+	}
+	first, err := parseTemplates(tmpl, paths, output, stdin)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = first
+	}
+	v, err := load(data, stdin)
+	if err != nil {
+		return err
+	}
+
+	b := bytes.Buffer{}
+	if err := tmpl.ExecuteTemplate(&b, name, v); err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = b.WriteTo(stdout)
+		return err
+	}
+	return ioutil.WriteFile(output, b.Bytes(), 0644)
+}
+
+// Parse the templates at `paths`, or stdin if there are none, into `tmpl` and return the name of
+// the first. Templates in files are named by their path relative to the directory given. The
+// `output` file is not a template, even if it is among them.
+func parseTemplates(tmpl *template.Template, paths []string, output string, stdin io.Reader) (string, error) {
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	if output != "" {
+		output, _ = filepath.Abs(output)
+	}
+	isOutput := func(p string) bool {
+		abs, _ := filepath.Abs(p)
+		return output != "" && abs == output
+	}
+	first := ""
+	sources := map[string]string{}
+	add := func(name, source string, text []byte) error {
+		if other, ok := sources[name]; ok {
+			return fmt.Errorf("%s and %s are both the template %q", other, source, name)
+		}
+		sources[name] = source
+		if first == "" {
+			first = name
+		}
+		_, err := tmpl.New(name).Parse(string(text))
+		return err
+	}
+	for _, path := range paths {
+		if path == "-" {
+			text, err := ioutil.ReadAll(stdin)
+			if err != nil {
+				return "", err
+			}
+			if err := add("stdin", path, text); err != nil {
+				return "", err
+			}
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		if isOutput(path) {
+			continue
+		}
+		if !info.IsDir() {
+			text, err := ioutil.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+			if err := add(filepath.Base(path), path, text); err != nil {
+				return "", err
+			}
+			continue
+		}
+		err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(info.Name(), ".") && p != path {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !info.Mode().IsRegular() || isOutput(p) {
+				return nil
+			}
+			text, err := ioutil.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(path, p)
+			if err != nil {
+				return err
+			}
+			return add(filepath.ToSlash(rel), p, text)
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	if first == "" {
+		return "", errors.New("no templates")
+	}
+	return first, nil
+}
+
+// Load and merge the data `files`. A single file may hold any value; several must hold maps.
+func load(paths []string, stdin io.Reader) (interface{}, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	merged := map[string]interface{}{}
+	for _, path := range paths {
+		v, err := loadFile(path, stdin)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if len(paths) == 1 {
+			return v, nil
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: %T is not a map", path, v)
+		}
+		for k, x := range m {
+			merged[k] = x
+		}
+	}
+	return merged, nil
+}
+
+func loadFile(path string, stdin io.Reader) (interface{}, error) {
+	var b []byte
+	var err error
+	if path == "-" {
+		b, err = ioutil.ReadAll(stdin)
+	} else {
+		b, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return funcmap.FromJSON(string(b))
+	case ".toml":
+		return funcmap.FromTOML(string(b))
+	case ".env":
+		return parseEnv(b)
+	}
+	return funcmap.FromYAML(string(b))
+}
+
+// KEY=VALUE lines, optionally prefixed with `export` and quoted. Blank lines and `#` comments
+// are ignored.
+func parseEnv(b []byte) (map[string]interface{}, error) {
+	env := map[string]interface{}{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		eq := strings.IndexByte(line, '=')
+		if eq < 1 {
+			return nil, fmt.Errorf("line %d is not KEY=VALUE", n)
+		}
+		k, v := strings.TrimSpace(line[:eq]), strings.TrimSpace(line[eq+1:])
+		if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+			v = v[1 : len(v)-1]
+		}
+		env[k] = v
+	}
+	return env, scanner.Err()
+}
+
+// RFC 3339, or the format of clock.Clock.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	now, err := clock.Clock(s).Time()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC 3339 time", s)
+	}
+	return now(), nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func write(t *testing.T, dir string, files map[string]string) {
+	for name, text := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(text), 0644))
+	}
+}
+
+//
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, map[string]string{
+		"site/page.tmpl":            `{{ template "partials/header.tmpl" . }}{{ .name | upper }} {{ .port }} {{ .TOKEN }}`,
+		"site/partials/header.tmpl": `# {{ .title }}{{ "\n" }}`,
+		"site/.hidden/x.tmpl":       `{{ nope }}`,
+		"one.json":                  `{"name": "api", "title": "JSON"}`,
+		"two.yaml":                  "title: YAML\nport: 8080\n",
+		"three.env":                 "# comment\nexport TOKEN='s3cret'\n",
+		"fixed.tmpl":                `{{ now.Year }} {{ uuid_v4 }}`,
+		"missing.tmpl":              `{{ .missing }}`,
+		"a/x.tmpl":                  `a`,
+		"b/x.tmpl":                  `b`,
+	})
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		want   string
+		code   int
+		errors string
+	}{
+		{name: "stdin", args: nil, stdin: `{{ "a,b" | split "," | join "-" }}`, want: "a-b"},
+		{name: "directory", args: []string{"-data", path("one.json"), "-data", path("two.yaml"), "-data", path("three.env"), path("site")},
+			want: "# YAML\nAPI 8080 s3cret"},
+		{name: "named", args: []string{"-data", path("one.json"), "-template", "partials/header.tmpl", path("site")}, want: "# JSON\n"},
+		{name: "stdin data", args: []string{"-data", "-", path("missing.tmpl")}, stdin: `missing: [1]`, want: "[1]"},
+		{name: "lenient", args: []string{path("missing.tmpl")}, want: "<no value>"},
+		{name: "strict", args: []string{"-strict", "-data", path("one.json"), path("missing.tmpl")}, code: 1, errors: `map has no entry for key "missing"`},
+		{name: "v3", args: []string{"-map", "v3"}, stdin: `{{ sub 5 2 }}`, want: "3"},
+		{name: "v1", args: []string{"-map", "v1"}, stdin: `{{ sub 5 2 }}`, want: "-3"},
+		{name: "bad map", args: []string{"-map", "v9"}, code: 2, errors: `unknown map version "v9"`},
+		{name: "bad clock", args: []string{"-clock", "soon"}, code: 2, errors: `"soon" is not an RFC 3339 time`},
+		{name: "bad flag", args: []string{"-nope"}, code: 2, errors: "flag provided but not defined"},
+		{name: "help", args: []string{"-h"}, code: 0, errors: "usage: funcmap"},
+		{name: "no file", args: []string{path("nope.tmpl")}, code: 1, errors: "no such file"},
+		{name: "parse error", stdin: `{{ nope }}`, code: 1, errors: `function "nope" not defined`},
+		{name: "same name", args: []string{path("a/x.tmpl"), path("b/x.tmpl")}, code: 1, errors: `are both the template "x.tmpl"`},
+		{name: "both stdin", args: []string{"-data", "-"}, stdin: `{{ . }}`, code: 2, errors: "cannot both come from stdin"},
+		{name: "both stdin named", args: []string{"-data", "-", "-"}, stdin: `{{ . }}`, code: 2, errors: "cannot both come from stdin"},
+		{name: "not maps", args: []string{"-data", path("one.json"), "-data", "-", path("missing.tmpl")}, stdin: "[1]", code: 1, errors: "is not a map"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			assert.Equal(t, tt.code, code, stderr.String())
+			assert.Equal(t, tt.want, stdout.String())
+			assert.Contains(t, stderr.String(), tt.errors)
+		})
+	}
+}
+
+//
+func TestRunReproducible(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, map[string]string{"t.tmpl": `{{ now.Year }} {{ uuid_v4 }} {{ rand }}`})
+	out := filepath.Join(dir, "out.txt")
+	args := []string{"-seed", "7", "-clock", "2001-02-03T04:05:06Z", "-o", out, filepath.Join(dir, "t.tmpl")}
+
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	assert.Equal(t, 0, run(args, nil, &stdout, &stderr), stderr.String())
+	assert.Empty(t, stdout.String())
+	first, err := ioutil.ReadFile(out)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(first), "2001 "))
+
+	assert.Equal(t, 0, run(args, nil, &stdout, &stderr), stderr.String())
+	second, _ := ioutil.ReadFile(out)
+	assert.Equal(t, string(first), string(second))
+}
+
+//
+func TestRunIntoTemplates(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, map[string]string{"site/page.tmpl": `{{ next }} {{ "{{ 1 }}" }}`})
+	site := filepath.Join(dir, "site")
+	out := filepath.Join(site, "a.txt")
+	args := []string{"-o", out, site}
+
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, 0, run(args, nil, &stdout, &stderr), stderr.String())
+		got, err := ioutil.ReadFile(out)
+		assert.NoError(t, err)
+		assert.Equal(t, "1 {{ 1 }}", string(got))
+	}
+}
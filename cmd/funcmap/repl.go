@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/gomatic/funcmap"
+	"golang.org/x/term"
+)
+
+const replHelp = `Enter an expression, such as  ip_math "_._._.[+1]" "10.0.0.1"  to see its type and value,
+or template text, such as  {{ .name | upper }}, to see what it renders. Tab completes names.
+
+:funcs [prefix]     list the functions
+:doc name           describe a function
+:data               dump the data
+:set key expression set a key of the data to the value of an expression
+:help               show this help
+:quit               exit
+`
+
+// Evaluate expressions and templates, line by line, with the same functions and data.
+func replCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("funcmap repl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setup := setupFlags(flags)
+	if code, ok := parse(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() != 0 || contains(setup.data, "-") {
+		fmt.Fprintf(stderr, "usage: funcmap repl [flags], with -data from files\n")
+		return 2
+	}
+	funcs, err := setup.funcs()
+	if err != nil {
+		fmt.Fprintf(stderr, "funcmap: %v\n", err)
+		return 2
+	}
+	data, err := load(setup.data, nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "funcmap: %v\n", err)
+		return 1
+	}
+	r := &repl{funcs: funcs, data: data}
+
+	if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			fmt.Fprintf(stderr, "funcmap: %v\n", err)
+			return 1
+		}
+		defer term.Restore(int(f.Fd()), state)
+		t := term.NewTerminal(struct {
+			io.Reader
+			io.Writer
+		}{stdin, stdout}, "> ")
+		t.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+			if key != '\t' {
+				return "", 0, false
+			}
+			return r.complete(line, pos)
+		}
+		fmt.Fprint(t, "Type :help for help.\n")
+		r.loop(t.ReadLine, t)
+		return 0
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	r.loop(func() (string, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.EOF
+		}
+		return scanner.Text(), nil
+	}, stdout)
+	return 0
+}
+
+type repl struct {
+	funcs template.FuncMap
+	data  interface{}
+}
+
+var errQuit = errors.New("quit")
+
+// Evaluate the lines from `read` until it fails or there is a :quit.
+func (r *repl) loop(read func() (string, error), w io.Writer) {
+	for {
+		line, err := read()
+		if err != nil {
+			return
+		}
+		out, err := r.eval(strings.TrimSpace(line))
+		if err == errQuit {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(w, "error: %v\n", err)
+			continue
+		}
+		if out != "" {
+			fmt.Fprintln(w, strings.TrimSuffix(out, "\n"))
+		}
+	}
+}
+
+// The result of a line: a command, template text, or an expression.
+func (r *repl) eval(line string) (string, error) {
+	switch {
+	case line == "":
+		return "", nil
+	case strings.HasPrefix(line, ":"):
+		return r.command(line)
+	case strings.Contains(line, "{{"):
+		return r.execute(line)
+	}
+	v, err := funcmap.Eval(r.funcs, line, r.data)
+	if err != nil {
+		return "", err
+	}
+	return funcmap.Debug(v), nil
+}
+
+func (r *repl) command(line string) (string, error) {
+	fields := strings.Fields(line)
+	switch name, args := fields[0], fields[1:]; {
+	case name == ":quit" || name == ":q":
+		return "", errQuit
+	case name == ":help":
+		return replHelp, nil
+	case name == ":funcs":
+		prefix := ""
+		if len(args) != 0 {
+			prefix = args[0]
+		}
+		return strings.Join(r.names(prefix), " "), nil
+	case name == ":doc" && len(args) == 1:
+		f, ok := r.funcs[args[0]]
+		if !ok {
+			return "", fmt.Errorf("no function %q", args[0])
+		}
+		s := fmt.Sprintf("%s %s", args[0], reflect.TypeOf(f))
+		if d, ok := funcmap.Describe(r.funcs, args[0]); ok {
+			s += "\n" + d.Summary
+			if d.Example != "" {
+				s += "\n" + d.Example
+			}
+			if d.Output != "" {
+				s += "\n" + d.Output
+			}
+		}
+		return s, nil
+	case name == ":data":
+		return funcmap.Dump(r.data), nil
+	case name == ":set" && len(args) >= 2:
+		expression := strings.TrimSpace(line[len(name):])
+		v, err := funcmap.Eval(r.funcs, strings.TrimSpace(expression[len(args[0]):]), r.data)
+		if err != nil {
+			return "", err
+		}
+		if r.data == nil {
+			r.data = map[string]interface{}{}
+		}
+		m, ok := r.data.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("the data is a %T, not a map", r.data)
+		}
+		m[args[0]] = v
+		return funcmap.Debug(v), nil
+	}
+	return "", fmt.Errorf("unknown command %q; try :help", line)
+}
+
+// Render template text with the data.
+func (r *repl) execute(text string) (string, error) {
+	tmpl, err := template.New("repl").Funcs(r.funcs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	b := bytes.Buffer{}
+	err = tmpl.Execute(&b, r.data)
+	return b.String(), err
+}
+
+// The function and command names that start with `prefix`.
+func (r *repl) names(prefix string) []string {
+	names := []string{}
+	if strings.HasPrefix(prefix, ":") {
+		for _, c := range []string{":data", ":doc", ":funcs", ":help", ":quit", ":set"} {
+			if strings.HasPrefix(c, prefix) {
+				names = append(names, c)
+			}
+		}
+		return names
+	}
+	for n := range r.funcs {
+		if strings.HasPrefix(n, prefix) {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Complete the name before `pos` in `line`, or as much of it as the names have in common.
+func (r *repl) complete(line string, pos int) (string, int, bool) {
+	start := pos
+	for start > 0 && isNameByte(line[start-1]) {
+		start--
+	}
+	if start > 0 && line[start-1] == ':' && strings.TrimSpace(line[:start-1]) == "" {
+		start--
+	}
+	if start == pos || start > 0 && (line[start-1] == '.' || line[start-1] == '$') {
+		return "", 0, false
+	}
+	names := r.names(line[start:pos])
+	if len(names) == 0 {
+		return "", 0, false
+	}
+	completion := names[0]
+	for _, n := range names[1:] {
+		for !strings.HasPrefix(n, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+	if len(names) == 1 && !strings.HasPrefix(line[pos:], " ") {
+		completion += " "
+	}
+	return line[:start] + completion + line[pos:], start + len(completion), true
+}
+
+func isNameByte(b byte) bool {
+	return b == '_' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
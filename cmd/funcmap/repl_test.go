@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestRepl(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, map[string]string{"data.yaml": "name: api\n"})
+	script := strings.Join([]string{
+		`.name | upper`,
+		`{{ .name }}-{{ uuid_v4 }}`,
+		`sub 5 2`,
+		`:set port add 8000 80`,
+		`{{ .name }}:{{ .port }}`,
+		`nope 1`,
+		`:doc to_json`,
+		`:funcs ip4_`,
+		`:quit`,
+		`"never"`,
+	}, "\n")
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	args := []string{"repl", "-map", "v3", "-seed", "1", "-data", filepath.Join(dir, "data.yaml")}
+	assert.Equal(t, 0, run(args, strings.NewReader(script), &stdout, &stderr), stderr.String())
+	lines := strings.Split(stdout.String(), "\n")
+	assert.Equal(t, "string API", lines[0])
+	assert.Regexp(t, `^api-[0-9a-f-]{36}$`, lines[1])
+	assert.Equal(t, "int64 3", lines[2])
+	assert.Equal(t, "int64 8080", lines[3])
+	assert.Equal(t, "api:8080", lines[4])
+	assert.Contains(t, lines[5], `error: template: eval:1: function "nope" not defined`)
+	assert.Equal(t, "to_json func(interface {}) (string, error)", lines[6])
+	assert.Equal(t, "Compact JSON.", lines[7])
+	assert.Equal(t, `{{ to_json (split "," "a,b") }}`, lines[8])
+	assert.Equal(t, `["a","b"]`, lines[9])
+	assert.Equal(t, "ip4_add ip4_inc ip4_join ip4_next ip4_prev", lines[10])
+	assert.NotContains(t, stdout.String(), "never")
+
+	assert.Equal(t, 2, run([]string{"repl", "-data", "-"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"repl", "extra"}, nil, &stdout, &stderr))
+}
+
+//
+func TestComplete(t *testing.T) {
+	r := &repl{funcs: mapVersion("v1").funcs()}
+	tests := []struct {
+		line string
+		pos  int
+		want string
+		at   int
+		ok   bool
+	}{
+		{line: "to_js", pos: 5, want: "to_json ", at: 8, ok: true},
+		{line: "ip4_ne", pos: 6, want: "ip4_next ", at: 9, ok: true},
+		{line: "ip4_", pos: 4, want: "ip4_", at: 4, ok: true},
+		{line: "ip4_j 1 2 3", pos: 5, want: "ip4_join 1 2 3", at: 8, ok: true},
+		{line: "{{ .name | upp }}", pos: 14, want: "{{ .name | upper }}", at: 16, ok: true},
+		{line: ":q", pos: 2, want: ":quit ", at: 6, ok: true},
+		{line: "{{ .upp", pos: 7},
+		{line: "zzz", pos: 3},
+		{line: "", pos: 0},
+	}
+	for _, test := range tests {
+		line, at, ok := r.complete(test.line, test.pos)
+		assert.Equal(t, test.ok, ok, test.line)
+		assert.Equal(t, test.want, line, test.line)
+		assert.Equal(t, test.at, at, test.line)
+	}
+}
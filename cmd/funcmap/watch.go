@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Call `render` now, and again whenever a file at or below `paths` changes, until `stop` is closed.
+// A burst of changes is rendered once, after the files are unchanged for an `interval`. Errors are
+// reported to `stderr` and the watching goes on.
+func watch(paths []string, ignore string, interval time.Duration, stop <-chan struct{}, render func() error, stderr io.Writer) {
+	report := func() {
+		if err := render(); err != nil {
+			fmt.Fprintf(stderr, "funcmap: %v\n", err)
+			return
+		}
+		fmt.Fprintf(stderr, "funcmap: rendered at %s\n", time.Now().Format("15:04:05"))
+	}
+	last := snapshot(paths, ignore)
+	report()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	pending := false
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		current := snapshot(paths, ignore)
+		if !current.equal(last) {
+			last, pending = current, true
+			continue
+		}
+		if pending {
+			pending = false
+			report()
+		}
+	}
+}
+
+type stamp struct {
+	modified time.Time
+	size     int64
+}
+
+type stamps map[string]stamp
+
+func (s stamps) equal(o stamps) bool {
+	if len(s) != len(o) {
+		return false
+	}
+	for path, st := range s {
+		if ot, ok := o[path]; !ok || !ot.modified.Equal(st.modified) || ot.size != st.size {
+			return false
+		}
+	}
+	return true
+}
+
+// The stamps of the regular files at or below `paths`, the way parseTemplates finds them, other
+// than `ignore`. Missing files are left out, so that removing one is a change too.
+func snapshot(paths []string, ignore string) stamps {
+	s := stamps{}
+	if ignore != "" {
+		ignore, _ = filepath.Abs(ignore)
+	}
+	for _, path := range paths {
+		filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if strings.HasPrefix(info.Name(), ".") && p != path {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if abs, _ := filepath.Abs(p); !info.Mode().IsRegular() || abs == ignore {
+				return nil
+			}
+			s[p] = stamp{modified: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+	return s
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type lockedBuffer struct {
+	sync.Mutex
+	b bytes.Buffer
+}
+
+func (l *lockedBuffer) Write(p []byte) (int, error) {
+	l.Lock()
+	defer l.Unlock()
+	return l.b.Write(p)
+}
+
+func (l *lockedBuffer) String() string {
+	l.Lock()
+	defer l.Unlock()
+	return l.b.String()
+}
+
+//
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, map[string]string{
+		"site/page.tmpl": `{{ .name | upper }}`,
+		"data.yaml":      "name: api\n",
+	})
+	site, data, output := filepath.Join(dir, "site"), filepath.Join(dir, "data.yaml"), filepath.Join(dir, "site", "out.txt")
+	funcs := mapVersion("v1").funcs()
+
+	rendered := make(chan error, 10)
+	stop := make(chan struct{})
+	stderr := &lockedBuffer{}
+	done := make(chan struct{})
+	go func() {
+		watch([]string{site, data}, output, 10*time.Millisecond, stop, func() error {
+			err := render(funcs, false, "page.tmpl", []string{site}, []string{data}, output, nil, nil)
+			rendered <- err
+			return err
+		}, stderr)
+		close(done)
+	}()
+	next := func() error {
+		select {
+		case err := <-rendered:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("not rendered")
+			return nil
+		}
+	}
+	contents := func() string {
+		b, _ := ioutil.ReadFile(output)
+		return string(b)
+	}
+
+	assert.NoError(t, next())
+	assert.Equal(t, "API", contents())
+
+	// A burst of saves renders once.
+	for _, name := range []string{"a", "b", "c"} {
+		write(t, dir, map[string]string{"data.yaml": "name: " + name + "\n"})
+	}
+	assert.NoError(t, next())
+	assert.Equal(t, "C", contents())
+
+	// An error is reported and the last output is kept.
+	write(t, dir, map[string]string{"site/partial.tmpl": `{{ nope }}`})
+	assert.Error(t, next())
+	assert.Equal(t, "C", contents())
+	assert.Contains(t, stderr.String(), `funcmap: template: partial.tmpl:1: function "nope" not defined`)
+
+	write(t, dir, map[string]string{"site/partial.tmpl": `{{ define "x" }}{{ end }}`})
+	assert.NoError(t, next())
+
+	close(stop)
+	<-done
+	assert.Empty(t, rendered)
+}
+
+//
+func TestWatchUsage(t *testing.T) {
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	assert.Equal(t, 2, run([]string{"-watch"}, nil, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"-watch", "-data", "-", "page.tmpl"}, nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "-watch needs template and data files, not stdin")
+}
+
+//
+func TestRunWatch(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, map[string]string{"site/page.tmpl": `{{ next }} {{ "{{ 1 }}" }}`})
+	site := filepath.Join(dir, "site")
+	out := filepath.Join(site, "a.txt")
+	stop := make(chan struct{})
+	stopWatching = stop
+	defer func() { stopWatching = nil }()
+
+	stderr := &lockedBuffer{}
+	done := make(chan int)
+	go func() {
+		done <- run([]string{"-watch", "-interval", "10ms", "-o", out, site}, nil, nil, stderr)
+	}()
+	wait := func(want string) {
+		for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+			if b, _ := ioutil.ReadFile(out); string(b) == want {
+				return
+			}
+		}
+		t.Fatalf("%s is not %q; %s", out, want, stderr.String())
+	}
+
+	wait("1 {{ 1 }}")
+	// Rendered again with fresh sequences, and without the output as a template.
+	write(t, dir, map[string]string{"site/page.tmpl": `{{ next }} {{ "{{ 2 }}" }}`})
+	wait("1 {{ 2 }}")
+
+	close(stop)
+	assert.Equal(t, 0, <-done)
+}
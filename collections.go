@@ -0,0 +1,394 @@
+package funcmap
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//
+func First(list interface{}) (interface{}, error) {
+	is, err := items(list)
+	if err != nil || len(is) == 0 {
+		return nil, err
+	}
+	return is[0], nil
+}
+
+//
+func Last(list interface{}) (interface{}, error) {
+	is, err := items(list)
+	if err != nil || len(is) == 0 {
+		return nil, err
+	}
+	return is[len(is)-1], nil
+}
+
+// All but the first item.
+func Rest(list interface{}) ([]interface{}, error) {
+	is, err := items(list)
+	if err != nil || len(is) == 0 {
+		return is, err
+	}
+	return is[1:], nil
+}
+
+// Items `start` up to, not including, `end`. Negative indexes count from the end and out of range
+// indexes are clamped.
+func Sublist(start, end int, list interface{}) ([]interface{}, error) {
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	clamp := func(i int) int {
+		if i < 0 {
+			i += len(is)
+		}
+		if i < 0 {
+			return 0
+		}
+		if i > len(is) {
+			return len(is)
+		}
+		return i
+	}
+	start, end = clamp(start), clamp(end)
+	if start >= end {
+		return []interface{}{}, nil
+	}
+	return is[start:end], nil
+}
+
+//
+func Reverse(list interface{}) ([]interface{}, error) {
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(is)-1; i < j; i, j = i+1, j-1 {
+		is[i], is[j] = is[j], is[i]
+	}
+	return is, nil
+}
+
+// Numbers sort numerically, strings lexically, and anything else by its `%v` format.
+func Sort(list interface{}) ([]interface{}, error) {
+	return SortBy("", list)
+}
+
+// Sort, as by Sort, by the value at `path` in each item. See Get for the path syntax.
+func SortBy(path string, list interface{}) ([]interface{}, error) {
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]reflect.Value, len(is))
+	for i, item := range is {
+		keys[i], _ = lookup(reflect.ValueOf(item), path)
+	}
+	sort.Stable(byKey{is, keys})
+	return is, nil
+}
+
+// The items without duplicates, keeping the first of each.
+func Uniq(list interface{}) ([]interface{}, error) {
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	uniq := []interface{}{}
+	for _, item := range is {
+		if !includes(uniq, item) {
+			uniq = append(uniq, item)
+		}
+	}
+	return uniq, nil
+}
+
+// Whether a list includes `item`, a map has the key `item`, or a string contains `item`.
+func Includes(item, collection interface{}) (bool, error) {
+	v := indirect(reflect.ValueOf(collection))
+	switch v.Kind() {
+	case reflect.Invalid:
+		return false, nil
+	case reflect.String:
+		return strings.Contains(v.String(), fmt.Sprint(item)), nil
+	case reflect.Map:
+		k, ok := mapKey(v, item)
+		return ok && v.MapIndex(k).IsValid(), nil
+	}
+	is, err := items(collection)
+	if err != nil {
+		return false, err
+	}
+	return includes(is, item), nil
+}
+
+// The items whose value at `path` equals `value`. See Get.
+func Where(path string, value, list interface{}) ([]interface{}, error) {
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	where := []interface{}{}
+	for _, item := range is {
+		if v, ok := lookup(reflect.ValueOf(item), path); ok && equal(v, reflect.ValueOf(value)) {
+			where = append(where, item)
+		}
+	}
+	return where, nil
+}
+
+// The items grouped by the `%v` format of their value at `path`. See Get.
+func GroupBy(path string, list interface{}) (map[string][]interface{}, error) {
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	groups := map[string][]interface{}{}
+	for _, item := range is {
+		v, _ := lookup(reflect.ValueOf(item), path)
+		k := format(v)
+		groups[k] = append(groups[k], item)
+	}
+	return groups, nil
+}
+
+// The values at `path` of the items that have one. See Get.
+func PluckPath(path string, list interface{}) ([]interface{}, error) {
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	plucked := []interface{}{}
+	for _, item := range is {
+		if v, ok := lookup(reflect.ValueOf(item), path); ok {
+			plucked = append(plucked, v.Interface())
+		}
+	}
+	return plucked, nil
+}
+
+// The keys of `m`, sorted as by Sort.
+func SortedKeys(m interface{}) ([]interface{}, error) {
+	v := indirect(reflect.ValueOf(m))
+	if v.Kind() == reflect.Invalid {
+		return []interface{}{}, nil
+	}
+	if v.Kind() != reflect.Map {
+		return nil, fmt.Errorf("%T is not a map", m)
+	}
+	keys := make([]interface{}, v.Len())
+	for i, k := range v.MapKeys() {
+		keys[i] = k.Interface()
+	}
+	return Sort(keys)
+}
+
+// The values of `m`, ordered by their sorted keys.
+func SortedValues(m interface{}) ([]interface{}, error) {
+	keys, err := SortedKeys(m)
+	if err != nil {
+		return nil, err
+	}
+	v := indirect(reflect.ValueOf(m))
+	values := make([]interface{}, len(keys))
+	for i, k := range keys {
+		values[i] = v.MapIndex(reflect.ValueOf(k)).Interface()
+	}
+	return values, nil
+}
+
+// Merge maps into a new map, keys of later maps taking precedence. Nested maps are merged the same way.
+func MergeMaps(maps ...interface{}) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+	for _, m := range maps {
+		v := indirect(reflect.ValueOf(m))
+		if v.Kind() == reflect.Invalid {
+			continue
+		}
+		if v.Kind() != reflect.Map {
+			return nil, fmt.Errorf("%T is not a map", m)
+		}
+		for _, k := range v.MapKeys() {
+			key := format(k)
+			value := v.MapIndex(k).Interface()
+			if indirect(reflect.ValueOf(value)).Kind() == reflect.Map {
+				if prior := indirect(reflect.ValueOf(merged[key])); prior.Kind() == reflect.Map {
+					nested, err := MergeMaps(prior.Interface(), value)
+					if err != nil {
+						return nil, err
+					}
+					value = nested
+				}
+			}
+			merged[key] = value
+		}
+	}
+	return merged, nil
+}
+
+// Split the items into lists of `size`; the last may be shorter.
+func Chunk(size int, list interface{}) ([][]interface{}, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("chunk size %d is not positive", size)
+	}
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	chunks := [][]interface{}{}
+	for len(is) > size {
+		chunks = append(chunks, is[:size:size])
+		is = is[size:]
+	}
+	if len(is) != 0 {
+		chunks = append(chunks, is)
+	}
+	return chunks, nil
+}
+
+// Pairs of the items of `a` and `b`, as long as the shorter.
+func Zip(a, b interface{}) ([][]interface{}, error) {
+	as, err := items(a)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := items(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(bs) < len(as) {
+		as = as[:len(bs)]
+	}
+	zipped := make([][]interface{}, len(as))
+	for i := range as {
+		zipped[i] = []interface{}{as[i], bs[i]}
+	}
+	return zipped, nil
+}
+
+// A copy of the items of any slice or array. nil is empty.
+func items(list interface{}) ([]interface{}, error) {
+	v := indirect(reflect.ValueOf(list))
+	switch v.Kind() {
+	case reflect.Invalid:
+		return []interface{}{}, nil
+	case reflect.Slice, reflect.Array:
+		is := make([]interface{}, v.Len())
+		for i := range is {
+			is[i] = v.Index(i).Interface()
+		}
+		return is, nil
+	}
+	return nil, fmt.Errorf("%T is not a list", list)
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func float(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+func format(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Order numbers numerically, strings lexically, and anything else by its `%v` format.
+// Invalid values order first.
+func compare(a, b reflect.Value) int {
+	a, b = indirect(a), indirect(b)
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return -1
+	case !b.IsValid():
+		return 1
+	case isNumber(a) && isNumber(b):
+		if a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return cmp(a.Int() < b.Int(), a.Int() > b.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return cmp(a.Uint() < b.Uint(), a.Uint() > b.Uint())
+			}
+		}
+		return cmp(float(a) < float(b), float(a) > float(b))
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		return cmp(!a.Bool() && b.Bool(), a.Bool() && !b.Bool())
+	}
+	return strings.Compare(format(a), format(b))
+}
+
+func cmp(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+func equal(a, b reflect.Value) bool {
+	a, b = indirect(a), indirect(b)
+	if isNumber(a) && isNumber(b) || a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return compare(a, b) == 0
+	}
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func includes(is []interface{}, item interface{}) bool {
+	for _, i := range is {
+		if equal(reflect.ValueOf(i), reflect.ValueOf(item)) {
+			return true
+		}
+	}
+	return false
+}
+
+type byKey struct {
+	items []interface{}
+	keys  []reflect.Value
+}
+
+func (s byKey) Len() int           { return len(s.items) }
+func (s byKey) Less(i, j int) bool { return compare(s.keys[i], s.keys[j]) < 0 }
+func (s byKey) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
@@ -0,0 +1,223 @@
+package funcmap
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type collectionItem struct {
+	Name  string
+	Size  int
+	Attrs map[string]interface{}
+}
+
+var collectionItems = []collectionItem{
+	{Name: "b", Size: 2, Attrs: map[string]interface{}{"zone": "east"}},
+	{Name: "a", Size: 3, Attrs: map[string]interface{}{"zone": "west"}},
+	{Name: "c", Size: 1, Attrs: map[string]interface{}{"zone": "east"}},
+}
+
+func collectionNames(t *testing.T, is []interface{}) []string {
+	names, err := PluckPath("Name", is)
+	assert.NoError(t, err)
+	ss := make([]string, len(names))
+	for i, n := range names {
+		ss[i] = n.(string)
+	}
+	return ss
+}
+
+//
+func TestFirstLastRest(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     interface{}
+		first    interface{}
+		last     interface{}
+		rest     []interface{}
+		wantErrs bool
+	}{
+		{name: "nil", args: nil, rest: []interface{}{}},
+		{name: "empty", args: []string{}, rest: []interface{}{}},
+		{name: "strings", args: []string{"a", "b", "c"}, first: "a", last: "c", rest: []interface{}{"b", "c"}},
+		{name: "array", args: [2]int{1, 2}, first: 1, last: 2, rest: []interface{}{2}},
+		{name: "pointer", args: &[]int64{1}, first: int64(1), last: int64(1), rest: []interface{}{}},
+		{name: "scalar", args: 1, wantErrs: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := First(tt.args)
+			assert.Equal(t, tt.wantErrs, err != nil)
+			last, _ := Last(tt.args)
+			rest, _ := Rest(tt.args)
+			if tt.wantErrs {
+				return
+			}
+			assert.Equal(t, tt.first, first)
+			assert.Equal(t, tt.last, last)
+			assert.Equal(t, tt.rest, rest)
+		})
+	}
+}
+
+//
+func TestSublist(t *testing.T) {
+	list := []int{0, 1, 2, 3, 4}
+	tests := []struct {
+		start, end int
+		want       []interface{}
+	}{
+		{0, 5, []interface{}{0, 1, 2, 3, 4}},
+		{1, 3, []interface{}{1, 2}},
+		{-2, 5, []interface{}{3, 4}},
+		{0, -1, []interface{}{0, 1, 2, 3}},
+		{-10, 10, []interface{}{0, 1, 2, 3, 4}},
+		{3, 1, []interface{}{}},
+	}
+	for _, tt := range tests {
+		got, err := Sublist(tt.start, tt.end, list)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%d:%d", tt.start, tt.end)
+	}
+}
+
+//
+func TestReverseSortUniq(t *testing.T) {
+	got, err := Reverse([]string{"a", "b", "c"})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"c", "b", "a"}, got)
+
+	got, err = Sort([]interface{}{10, 2.5, int64(-1), uint8(3)})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(-1), 2.5, uint8(3), 10}, got)
+
+	got, err = Sort([]string{"b", "c", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, got)
+
+	got, err = SortBy("Size", collectionItems)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "b", "a"}, collectionNames(t, got))
+
+	got, err = SortBy("Attrs.zone", collectionItems)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c", "a"}, collectionNames(t, got))
+
+	got, err = Uniq([]interface{}{1, int64(1), "1", 2, 1.0, []int{1}, []int{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, "1", 2, []int{1}}, got)
+}
+
+//
+func TestIncludes(t *testing.T) {
+	tests := []struct {
+		name       string
+		item       interface{}
+		collection interface{}
+		want       bool
+		wantErr    bool
+	}{
+		{name: "list", item: 2, collection: []int64{1, 2}, want: true},
+		{name: "list missing", item: 3, collection: []int64{1, 2}, want: false},
+		{name: "map key", item: "a", collection: map[string]int{"a": 1}, want: true},
+		{name: "map int key", item: "1", collection: map[int]string{1: "a"}, want: true},
+		{name: "map missing", item: "b", collection: map[string]int{"a": 1}, want: false},
+		{name: "string", item: "ell", collection: "hello", want: true},
+		{name: "nil", item: 1, collection: nil, want: false},
+		{name: "scalar", item: 1, collection: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Includes(tt.item, tt.collection)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Includes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Includes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestWhereGroupBy(t *testing.T) {
+	got, err := Where("Attrs.zone", "east", collectionItems)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, collectionNames(t, got))
+
+	got, err = Where("Size", int64(3), &collectionItems)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, collectionNames(t, got))
+
+	groups, err := GroupBy("Attrs.zone", collectionItems)
+	assert.NoError(t, err)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, []string{"b", "c"}, collectionNames(t, groups["east"]))
+	assert.Equal(t, []string{"a"}, collectionNames(t, groups["west"]))
+
+	plucked, err := PluckPath("x.1", []interface{}{map[string][]int{"x": {1, 2}}, map[string][]int{"y": {3}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{2}, plucked)
+}
+
+//
+func TestSortedKeysValues(t *testing.T) {
+	m := map[int]string{3: "c", 1: "a", 2: "b"}
+	keys, err := SortedKeys(m)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, keys)
+	values, err := SortedValues(m)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, values)
+	_, err = SortedKeys([]int{})
+	assert.Error(t, err)
+}
+
+//
+func TestMergeMaps(t *testing.T) {
+	got, err := MergeMaps(
+		map[string]interface{}{"a": 1, "n": map[string]interface{}{"x": 1, "y": 1}},
+		nil,
+		map[string]int{"b": 2},
+		map[string]interface{}{"a": 3, "n": map[string]int{"y": 2}},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a": 3,
+		"b": 2,
+		"n": map[string]interface{}{"x": 1, "y": 2},
+	}, got)
+
+	_, err = MergeMaps(map[string]int{}, []int{})
+	assert.Error(t, err)
+}
+
+//
+func TestChunkZip(t *testing.T) {
+	chunks, err := Chunk(2, []int{1, 2, 3, 4, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{1, 2}, {3, 4}, {5}}, chunks)
+	chunks, err = Chunk(2, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]interface{}{}, chunks)
+	_, err = Chunk(0, []int{1})
+	assert.Error(t, err)
+
+	zipped, err := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{"a", 1}, {"b", 2}}, zipped)
+}
+
+//
+func TestCollectionsTemplate(t *testing.T) {
+	list := template.FuncMap{"list": func() []int { return []int{1, 2, 3} }}
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map(), WithMap(list))).Parse(
+		`{{ range sort_by "Size" . }}{{ .Name }}{{ end }} {{ first . | printf "%v" }} {{ includes 2 (sublist 1 -1 (list)) }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, collectionItems))
+	assert.Equal(t, "cba {b 2 map[zone:east]} true", b.String())
+}
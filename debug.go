@@ -0,0 +1,76 @@
+package funcmap
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// A single traced function call.
+type Trace struct {
+	Name     string
+	Args     []interface{}
+	Results  []interface{}
+	Duration time.Duration
+}
+
+// Receives traced function calls.
+type Tracer func(Trace)
+
+// A Tracer that writes one line per call to `w`.
+func WriterTracer(w io.Writer) Tracer {
+	lock := sync.Mutex{}
+	return func(t Trace) {
+		lock.Lock()
+		defer lock.Unlock()
+		fmt.Fprintf(w, "%s(%s) = %s [%s]\n", t.Name, Debug(t.Args...), Debug(t.Results...), t.Duration)
+	}
+}
+
+// Wrap the function `f` so that each call is reported to `tracer` while `enabled` returns true.
+// Values of `f` that are not functions are returned as is.
+func Traced(name string, f interface{}, enabled func() bool, tracer Tracer) interface{} {
+	return Wrap(name, f, Tracing(enabled, tracer))
+}
+
+// A Middleware that reports each call to `tracer` while `enabled` returns true.
+func Tracing(enabled func() bool, tracer Tracer) Middleware {
+	if enabled == nil || tracer == nil {
+		return nil
+	}
+	return Around(func(name string, in []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value {
+		if !enabled() {
+			return call(in)
+		}
+		started := time.Now()
+		out := call(in)
+		tracer(Trace{
+			Name:     name,
+			Args:     interfaces(in),
+			Results:  interfaces(out),
+			Duration: time.Since(started),
+		})
+		return out
+	})
+}
+
+// Like Debug but writes the line to `w` so it stays out of the rendered output.
+func DebugTo(w io.Writer) func(...interface{}) string {
+	lock := sync.Mutex{}
+	return func(any ...interface{}) string {
+		lock.Lock()
+		defer lock.Unlock()
+		fmt.Fprintln(w, Debug(any...))
+		return ""
+	}
+}
+
+func interfaces(vs []reflect.Value) []interface{} {
+	is := make([]interface{}, len(vs))
+	for i, v := range vs {
+		is[i] = v.Interface()
+	}
+	return is
+}
@@ -0,0 +1,91 @@
+package funcmap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestTraced(t *testing.T) {
+	enabled := true
+	traces := []Trace{}
+	tracer := func(t Trace) { traces = append(traces, t) }
+
+	add := Traced("add", Add, func() bool { return enabled }, tracer).(func(int64, int64) int64)
+	step := Traced("inc", Step, func() bool { return enabled }, tracer).(func(int64, ...int) int64)
+
+	assert.Equal(t, int64(3), add(1, 2))
+	assert.Equal(t, int64(4), step(1, 1, 2))
+	enabled = false
+	assert.Equal(t, int64(5), add(2, 3))
+
+	assert.Len(t, traces, 2)
+	assert.Equal(t, "add", traces[0].Name)
+	assert.Equal(t, []interface{}{int64(1), int64(2)}, traces[0].Args)
+	assert.Equal(t, []interface{}{int64(3)}, traces[0].Results)
+	assert.Equal(t, []interface{}{int64(1), []int{1, 2}}, traces[1].Args)
+
+	assert.Equal(t, "x", Traced("x", "x", func() bool { return true }, tracer))
+}
+
+//
+func TestWithTrace(t *testing.T) {
+	log := bytes.Buffer{}
+	render := func(source string, options ...Optional) string {
+		tmpl := template.Must(template.New("").Funcs(New(options...)).Parse(source))
+		b := bytes.Buffer{}
+		assert.NoError(t, tmpl.Execute(&b, nil))
+		return b.String()
+	}
+
+	got := render(`{{ add 1 2 }}{{ if debug_toggle }}{{ sub 1 2 }}{{ end }}`, WithV1Map(), WithTrace(WriterTracer(&log)))
+	assert.Equal(t, "31", got)
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	assert.Len(t, lines, 1)
+	assert.True(t, strings.HasPrefix(lines[0], "sub(int64 1 int64 2) = int64 1 ["), lines[0])
+
+	log.Reset()
+	render(`{{ add 1 2 }}`, WithV1Map(), WithTrace(WriterTracer(&log)), WithDebugging())
+	assert.Contains(t, log.String(), "add(int64 1 int64 2) = int64 3 [")
+
+	assert.False(t, debugging())
+}
+
+//
+func TestWithDebugWriter(t *testing.T) {
+	w := bytes.Buffer{}
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map(), WithDebugWriter(&w))).Parse(`a{{ debug 1 "x" }}b`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, nil))
+	assert.Equal(t, "ab", b.String())
+	assert.Equal(t, "int 1 string x\n", w.String())
+
+	w.Reset()
+	tmpl = template.Must(template.New("").Funcs(New(WithV1Map(), WithDebugWriter(&w))).Parse(
+		`{{ add 1 2 }}{{ if debug_toggle }}{{ sub 1 2 }}{{ end }}{{ if debugging }}{{ mul 2 3 }}{{ end }}`))
+	b.Reset()
+	assert.NoError(t, tmpl.Execute(&b, nil))
+	assert.Equal(t, "316", b.String())
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "sub(int64 1 int64 2) = int64 1 ["), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "mul(int64 2 int64 3) = int64 6 ["), lines[1])
+	assert.False(t, debugging())
+}
+
+//
+func TestWithDebugging(t *testing.T) {
+	w := bytes.Buffer{}
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map(), WithDebugging(), WithDebugWriter(&w))).Parse(
+		`{{ add 1 2 }}{{ if debug_toggle }}{{ else }}{{ sub 1 2 }}{{ end }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, nil))
+	assert.Equal(t, "31", b.String())
+	lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+	assert.Len(t, lines, 1)
+	assert.True(t, strings.HasPrefix(lines[0], "add(int64 1 int64 2) = int64 3 ["), lines[0])
+}
@@ -0,0 +1,313 @@
+package funcmap
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+)
+
+// The documentation of a function.
+type Doc struct {
+	// The name the function is documented under.
+	Name    string
+	Summary string
+	// A template that calls the function, and its output when that is reproducible.
+	Example string
+	Output  string
+}
+
+// The documentation of the function registered as `name` in `funcs`, which may be an alias or a
+// variant, such as the v4 `trim`, of a documented function.
+func Describe(funcs template.FuncMap, name string) (Doc, bool) {
+	f, ok := funcs[name]
+	if !ok {
+		return Doc{}, false
+	}
+	if d, ok := docs[name]; ok && sameFunc(documented(name), f) {
+		d.Name = name
+		return d, true
+	}
+	candidates := []string{}
+	for n := range docs {
+		if sameFunc(documented(n), f) {
+			candidates = append(candidates, n)
+		}
+	}
+	if len(candidates) == 0 {
+		return sprigDoc(f, name)
+	}
+	sort.Strings(candidates)
+	best := candidates[0]
+	for _, n := range candidates {
+		if canonical(n) == canonical(name) {
+			best = n
+			break
+		}
+	}
+	d := docs[best]
+	d.Name = best
+	return d, true
+}
+
+// A Doc that points to sprig's documentation, if `f` is the sprig function `name`, or another.
+func sprigDoc(f interface{}, name string) (Doc, bool) {
+	if !sameFunc(sprigFuncs[name], f) {
+		names := []string{}
+		for n, g := range sprigFuncs {
+			if sameFunc(g, f) {
+				names = append(names, n)
+			}
+		}
+		if len(names) == 0 {
+			return Doc{}, false
+		}
+		sort.Strings(names)
+		name = names[0]
+	}
+	return Doc{Name: name, Summary: "A sprig function; see " + sprigURL + "."}, true
+}
+
+const sprigURL = "https://masterminds.github.io/sprig/"
+
+var sprigFuncs = sprig.GenericFuncMap()
+
+// The other names of the function registered as `name` in `funcs`: those that are spelled the
+// same but for case and underscores, such as `to_json` and `toJSON`, and are the same function.
+func Aliases(funcs template.FuncMap, name string) []string {
+	f, ok := funcs[name]
+	if !ok {
+		return nil
+	}
+	aliases := []string{}
+	for n, g := range funcs {
+		if n != name && canonical(n) == canonical(name) && sameFunc(f, g) {
+			aliases = append(aliases, n)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// A difference between two function maps.
+type Change struct {
+	Name string
+	// `added`, `removed` or `changed`.
+	Kind   string
+	Reason string
+}
+
+// The functions added to, removed from, or changed in `to` compared to `from`, by name.
+// Functions are compared by identity, so the maps should be built without middleware.
+func Compare(from, to template.FuncMap) []Change {
+	changes := []Change{}
+	for n, f := range from {
+		if g, exists := to[n]; !exists {
+			changes = append(changes, Change{Name: n, Kind: "removed"})
+		} else if reason := migration(f, g); reason != "" {
+			changes = append(changes, Change{Name: n, Kind: "changed", Reason: reason})
+		}
+	}
+	for n := range to {
+		if _, exists := from[n]; !exists {
+			changes = append(changes, Change{Name: n, Kind: "added"})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// The function that `docs[name]` documents.
+func documented(name string) interface{} {
+	if f, ok := v1Map[name]; ok {
+		return f
+	}
+	if f, ok := htmlMap[name]; ok {
+		return f
+	}
+	return cryptoMap[name]
+}
+
+// Whether `f` and `g` are the same function. Closures made by the same function are the same.
+func sameFunc(f, g interface{}) bool {
+	fv, gv := reflect.ValueOf(f), reflect.ValueOf(g)
+	return fv.Kind() == reflect.Func && gv.Kind() == reflect.Func && fv.Pointer() == gv.Pointer()
+}
+
+// `name` in lowercase without underscores, but for a trailing one.
+func canonical(name string) string {
+	trailing := ""
+	if strings.HasSuffix(name, "_") {
+		trailing, name = "_", strings.TrimSuffix(name, "_")
+	}
+	return strings.ToLower(strings.Replace(name, "_", "", -1)) + trailing
+}
+
+var docs = map[string]Doc{
+	"debug":          {Summary: "The type and value of each argument.", Example: `{{ debug 1 "a" }}`, Output: "int 1 string a"},
+	"dump":           {Summary: "A deep, multi-line dump of each argument.", Example: `{{ dump 1 }}`, Output: "(int) 1"},
+	"dump_depth":     {Summary: "Like dump but no deeper than the first argument.", Example: `{{ dump_depth 1 .numbers }}`},
+	"dump_json":      {Summary: "A value as indented JSON, marking cycles and values deeper than the first argument.", Example: `{{ dump_json 2 .numbers }}`},
+	"debugging":      {Summary: "Whether debugging is enabled. With a debug writer, calls are traced to it while it is.", Example: `{{ debugging }}`},
+	"debug_toggle":   {Summary: "Enable or disable debugging, and return whether it is enabled. See debugging.", Example: `{{ debug_toggle }}`},
+	"pause":          {Summary: "Sleep for a number of milliseconds and return the time.", Example: `{{ pause 10 }}`},
+	"command_line":   {Summary: "The command line of the process.", Example: `{{ command_line }}`},
+	"ip_math":        {Summary: "Apply per-group operations to an IP address; `_` leaves a group alone.", Example: `{{ ip_math "_._._.[+1]" "10.0.0.1" }}`, Output: "10.0.0.2"},
+	"ip4_inc":        {Summary: "Add to a zero-based group of an IPv4 address.", Example: `{{ ip4_inc 3 5 "10.0.0.1" }}`, Output: "10.0.0.6"},
+	"ip4_next":       {Summary: "Increment a group of an IPv4 address, cycling through `count` values from `lowest`.", Example: `{{ ip4_next 3 1 10 "10.0.0.10" }}`, Output: "10.0.0.1"},
+	"ip4_prev":       {Summary: "Decrement a group of an IPv4 address, cycling through `count` values from `lowest`.", Example: `{{ ip4_prev 3 0 0 "10.0.0.5" }}`, Output: "10.0.0.4"},
+	"ip4_add":        {Summary: "Add to a group of IPv4 address integers, cycling through `count` values from `lowest`.", Example: `{{ ip4_add 0 0 0 1 (ip_ints "1.2.3.4") }}`, Output: "[2 2 3 4]"},
+	"ip4_join":       {Summary: "Join IPv4 address integers.", Example: `{{ ip4_join (ip_ints "1.2.3.4") }}`, Output: "1.2.3.4"},
+	"ip6_inc":        {Summary: "Add to a zero-based group of an IPv6 address.", Example: `{{ ip6_inc 7 1 "fe80:0:0:0:0:0:0:ffff" }}`, Output: "fe80:0000:0000:0000:0000:0000:0000:0000"},
+	"ip6_next":       {Summary: "Increment a group of an IPv6 address, cycling through `count` values from `lowest`.", Example: `{{ ip6_next 7 1 10 "fe80:0:0:0:0:0:0:a" }}`, Output: "fe80:0000:0000:0000:0000:0000:0000:0001"},
+	"ip6_prev":       {Summary: "Decrement a group of an IPv6 address, cycling through `count` values from `lowest`.", Example: `{{ ip6_prev 7 0 0 "fe80:0:0:0:0:0:0:5" }}`, Output: "fe80:0000:0000:0000:0000:0000:0000:0004"},
+	"ip6_add":        {Summary: "Add to a group of IPv6 address integers, cycling through `count` values from `lowest`.", Example: `{{ ip6_add 7 0 0 1 (ip_ints "fe80:0:0:0:0:0:0:1") }}`, Output: "[65152 0 0 0 0 0 0 2]"},
+	"ip6_join":       {Summary: "Join IPv6 address integers as zero-padded hex.", Example: `{{ ip6_join (ip_ints "fe80:0:0:0:0:0:0:1") }}`, Output: "fe80:0000:0000:0000:0000:0000:0000:0001"},
+	"cidr_next":      {Summary: "Reserved; returns the address unchanged.", Example: `{{ cidr_next 24 0 0 1 (ip_ints "10.0.0.1") }}`, Output: "[10 0 0 1]"},
+	"ip_ints":        {Summary: "The groups of an IPv4, or hex IPv6, address as integers.", Example: `{{ ip_ints "10.0.0.1" }}`, Output: "[10 0 0 1]"},
+	"ip_split":       {Summary: "The groups of an IPv4 or IPv6 address.", Example: `{{ ip_split "10.0.0.1" }}`, Output: "[10 0 0 1]"},
+	"to_int":         {Summary: "Parse strings in a base, with zeros for those that fail.", Example: `{{ to_int 16 (split "," "ff,x") }}`, Output: "[255 0]"},
+	"dec_to_int":     {Summary: "Parse decimal strings, with zeros for those that fail.", Example: `{{ dec_to_int (split "," "1,2") }}`, Output: "[1 2]"},
+	"hex_to_int":     {Summary: "Parse hex strings, with zeros for those that fail.", Example: `{{ hex_to_int (split "," "a,b") }}`, Output: "[10 11]"},
+	"from_int":       {Summary: "Format integers with a fmt verb.", Example: `{{ from_int "%03d" (dec_to_int (split "," "1,2")) }}`, Output: "[001 002]"},
+	"to_int_strict":  {Summary: "Parse strings in a base, failing on any that are not integers.", Example: `{{ to_int_strict 10 (split "," "1,2") }}`, Output: "[1 2]"},
+	"invalid_ints":   {Summary: "The indexes of the strings that are not integers in a base.", Example: `{{ invalid_ints 10 (split "," "1,x,3") }}`, Output: "[1]"},
+	"from_int_base":  {Summary: "Format integers in a base from 2 to 36.", Example: `{{ from_int_base 2 (dec_to_int (split "," "2,5")) }}`, Output: "[10 101]"},
+	"parse_int":      {Summary: "Parse a 64-bit integer in a base, allowing a matching 0b, 0o or 0x prefix.", Example: `{{ parse_int 16 "0xff" }}`, Output: "255"},
+	"parse_uint":     {Summary: "Parse a 64-bit unsigned integer in a base.", Example: `{{ parse_uint 10 "18446744073709551615" }}`, Output: "18446744073709551615"},
+	"parse_int128":   {Summary: "Parse a 128-bit integer in a base.", Example: `{{ parse_int128 10 "-170141183460469231731687303715884105728" }}`, Output: "-170141183460469231731687303715884105728"},
+	"parse_uint128":  {Summary: "Parse a 128-bit unsigned integer in a base.", Example: `{{ parse_uint128 16 "ffffffffffffffffffffffffffffffff" }}`, Output: "340282366920938463463374607431768211455"},
+	"parse_uints":    {Summary: "Parse 64-bit unsigned integers in a base, failing on any that are not.", Example: `{{ parse_uints 10 (split "," "1,18446744073709551615") }}`, Output: "[1 18446744073709551615]"},
+	"parse_int128s":  {Summary: "Parse 128-bit integers in a base, failing on any that are not.", Example: `{{ parse_int128s 10 (split "," "-1,170141183460469231731687303715884105727") }}`, Output: "[-1 170141183460469231731687303715884105727]"},
+	"parse_uint128s": {Summary: "Parse 128-bit unsigned integers in a base, failing on any that are not.", Example: `{{ parse_uint128s 16 (split "," "ff,ffffffffffffffffffffffffffffffff") }}`, Output: "[255 340282366920938463463374607431768211455]"},
+	"format_int":     {Summary: "Zero-pad an integer to a width and group thousands.", Example: `{{ format_int 0 "," 1234567 }}`, Output: "1,234,567"},
+	"format_number":  {Summary: "Format a number with decimals and thousands and decimal separators.", Example: `{{ format_number 2 "." "," 1234.5 }}`, Output: "1.234,50"},
+	"to_base":        {Summary: "Format an integer in a base from 2 to 36.", Example: `{{ to_base 3 9 }}`, Output: "100"},
+	"to_binary":      {Summary: "Format an integer in base 2.", Example: `{{ to_binary 5 }}`, Output: "101"},
+	"to_octal":       {Summary: "Format an integer in base 8.", Example: `{{ to_octal 8 }}`, Output: "10"},
+	"to_hex":         {Summary: "Format an integer in base 16.", Example: `{{ to_hex 255 }}`, Output: "ff"},
+	"to_base36":      {Summary: "Format an integer in base 36.", Example: `{{ to_base36 35 }}`, Output: "z"},
+	"human_bytes":    {Summary: "A size in bytes in binary units.", Example: `{{ human_bytes 1536 }}`, Output: "1.5 KiB"},
+	"human_bytes_si": {Summary: "A size in bytes in decimal units.", Example: `{{ human_bytes_si 1500 }}`, Output: "1.5 kB"},
+	"duration":       {Summary: "A duration from a duration, a string such as 1h30m, or seconds.", Example: `{{ duration 90 }}`, Output: "1m30s"},
+	"human_duration": {Summary: "The two largest units of a duration.", Example: `{{ human_duration "26h" }}`, Output: "1d2h"},
+	"percent":        {Summary: "A ratio as a percentage with a number of decimals.", Example: `{{ percent 1 0.125 }}`, Output: "12.5%"},
+	"round_to":       {Summary: "Round half away from zero to a number of decimals.", Example: `{{ round_to 2 3.14159 }}`, Output: "3.14"},
+	"ceil":           {Summary: "The least integer not less than a number.", Example: `{{ ceil 1.2 }}`, Output: "2"},
+	"floor":          {Summary: "The greatest integer not greater than a number.", Example: `{{ floor 1.8 }}`, Output: "1"},
+	"roman":          {Summary: "Roman numerals for 1 through 3999.", Example: `{{ roman 1994 }}`, Output: "MCMXCIV"},
+	"next":           {Summary: "The next number of a sequence shared by every template.", Example: `{{ next }}`},
+	"keynext":        {Summary: "The next number of a sequence per key.", Example: `{{ keynext "doc" }}`},
+	"inc":            {Summary: "Add each of the rest to the first, or 1.", Example: `{{ inc 1 }} {{ inc 1 2 3 }}`, Output: "2 6"},
+	"add":            {Summary: "The second plus the first.", Example: `{{ add 1 2 }}`, Output: "3"},
+	"sub":            {Summary: "The second minus the first.", Example: `{{ sub 1 5 }}`, Output: "4"},
+	"mul":            {Summary: "The second times the first.", Example: `{{ mul 2 3 }}`, Output: "6"},
+	"div":            {Summary: "The second divided by the first, or 0 if the first is 0.", Example: `{{ div 2 7 }} {{ div 0 7 }}`, Output: "3 0"},
+	"div_":           {Summary: "The second divided by the first.", Example: `{{ div_ 2 7 }}`, Output: "3"},
+	"mod":            {Summary: "The second modulo the first.", Example: `{{ mod 3 7 }}`, Output: "1"},
+	"rand":           {Summary: "A non-negative random 63-bit integer. See WithSeed.", Example: `{{ rand }}`},
+	"uuid_v4":        {Summary: "A random UUID. See WithSeed.", Example: `{{ uuid_v4 }}`},
+	"uuid_v5":        {Summary: "A name-based UUID in a namespace: dns, url, oid, x500 or a UUID.", Example: `{{ uuid_v5 "dns" "example.com" }}`, Output: "cfbff0d1-9375-5685-968c-48ce8b15ae17"},
+	"ulid":           {Summary: "A 26 character, sortable ULID. See WithSeed and WithClock.", Example: `{{ ulid }}`},
+	"ksuid":          {Summary: "A 27 character, sortable KSUID. See WithSeed and WithClock.", Example: `{{ ksuid }}`},
+	"short_id":       {Summary: "A number of random letters and digits.", Example: `{{ short_id 8 }}`},
+	"slug_id":        {Summary: "A number of random lowercase letters and digits, starting with a letter.", Example: `{{ slug_id 8 }}`},
+	"semver_parse":   {Summary: "The major, minor, patch, prerelease, metadata and version of a semantic version.", Example: `{{ (semver_parse "v1.2.3-rc.1").minor }}`, Output: "2"},
+	"semver_bump":    {Summary: "Increment the major, minor, patch or prerelease of a version.", Example: `{{ "v1.2.3" | semver_bump "minor" }}`, Output: "v1.3.0"},
+	"semver_pre":     {Summary: "Replace the prerelease of a version.", Example: `{{ "1.2.3" | semver_pre "rc.1" }}`, Output: "1.2.3-rc.1"},
+	"semver_cmp":     {Summary: "-1, 0 or 1 as the second version is older than, the same as, or newer than the first.", Example: `{{ semver_cmp "1.2.3" "1.10.0" }}`, Output: "1"},
+	"semver_sort":    {Summary: "Sort versions, oldest first.", Example: `{{ semver_sort (split "," "1.10.0,1.2.0") }}`, Output: "[1.2.0 1.10.0]"},
+	"semver_check":   {Summary: "Whether a version satisfies a constraint.", Example: `{{ semver_check "^1.2" "1.4.0" }}`, Output: "true"},
+	"semver_max":     {Summary: "The newest version that satisfies a constraint, or empty.", Example: `{{ semver_max "~1.2" (split "," "1.2.0,1.2.5,1.3.0") }}`, Output: "1.2.5"},
+	"identifier":     {Summary: "Remove what cannot be in an identifier.", Example: `{{ identifier "9 lives-left" }}`, Output: "livesleft"},
+	"cleanse":        {Summary: "Remove all but letters.", Example: `{{ cleanse "a1-b2" }}`, Output: "ab"},
+	"cleanser":       {Summary: "Remove the matches of a regular expression.", Example: `{{ cleanser "[0-9]" "a1b2" }}`, Output: "ab"},
+	"env":            {Summary: "An environment variable, or empty. See WithEnv.", Example: `{{ env "HOME" }}`},
+	"env_default":    {Summary: "An environment variable, or a default if it is unset or empty.", Example: `{{ env_default "8080" "PORT" }}`},
+	"env_required":   {Summary: "An environment variable, or an error if it is unset.", Example: `{{ env_required "HOME" }}`, Output: "/home/gopher"},
+	"env_bool":       {Summary: "An environment variable as a bool, false if unset.", Example: `{{ env_bool "DEBUG" }}`},
+	"env_int":        {Summary: "An environment variable as an integer, 0 if unset.", Example: `{{ env_int "PORT" }}`},
+	"env_prefix":     {Summary: "The environment variables with a prefix, keyed by full name.", Example: `{{ env_prefix "APP_" }}`, Output: "map[APP_NAME:api]"},
+	"now":            {Summary: "The current time. See WithClock.", Example: `{{ now.Year }}`},
+	"started":        {Summary: "A function that returns the time it was made, for a consistent time.", Example: `{{ call started }}`},
+	"iindex":         {Summary: "An element of a list or a character of a string, or -1 if out of range. See get.", Example: `{{ iindex 1 "abc" }}`, Output: "b"},
+	"get":            {Summary: "The value at a path such as `a.b[0]`, or a default.", Example: `{{ get "a.b" "none" .map }}`},
+	"get_strict":     {Summary: "The value at a path such as `a.b[0]`, or an error.", Example: `{{ get_strict "[1]" (split "," "a,b") }}`, Output: "b"},
+	"first":          {Summary: "The first element of a list, or nil.", Example: `{{ first (split "," "a,b") }}`, Output: "a"},
+	"last":           {Summary: "The last element of a list, or nil.", Example: `{{ last (split "," "a,b") }}`, Output: "b"},
+	"rest":           {Summary: "All but the first element of a list.", Example: `{{ rest (split "," "a,b,c") }}`, Output: "[b c]"},
+	"sublist":        {Summary: "The elements from a start to an end index, which may be negative.", Example: `{{ sublist 1 -1 (split "," "a,b,c,d") }}`, Output: "[b c]"},
+	"reverse":        {Summary: "A list in reverse order.", Example: `{{ reverse (split "," "a,b") }}`, Output: "[b a]"},
+	"sort":           {Summary: "A list sorted numerically or lexically.", Example: `{{ sort (split "," "b,a") }}`, Output: "[a b]"},
+	"sort_by":        {Summary: "A list sorted by the value at a path in each element.", Example: `{{ sort_by "name" .people }}`},
+	"uniq":           {Summary: "A list without repeated elements.", Example: `{{ uniq (split "," "a,b,a") }}`, Output: "[a b]"},
+	"includes":       {Summary: "Whether a list, map key or string includes a value.", Example: `{{ includes "b" (split "," "a,b") }}`, Output: "true"},
+	"where":          {Summary: "The elements whose value at a path equals a value.", Example: `{{ where "kind" "web" .services }}`},
+	"group_by":       {Summary: "The elements by their value at a path.", Example: `{{ group_by "kind" .services }}`},
+	"pluck_path":     {Summary: "The value at a path in each element.", Example: `{{ pluck_path "name" .people }}`},
+	"sorted_keys":    {Summary: "The keys of a map, sorted.", Example: `{{ sorted_keys .map }}`},
+	"sorted_values":  {Summary: "The values of a map, sorted by key.", Example: `{{ sorted_values .map }}`},
+	"merge_maps":     {Summary: "Merge maps, the rightmost winning.", Example: `{{ merge_maps .defaults .overrides }}`},
+	"chunk":          {Summary: "A list in lists of a size.", Example: `{{ chunk 2 (split "," "a,b,c") }}`, Output: "[[a b] [c]]"},
+	"zip":            {Summary: "Pairs of elements of two lists, as long as the shorter.", Example: `{{ zip (split "," "a,b") (split "," "1,2") }}`, Output: "[[a 1] [b 2]]"},
+	"split":          {Summary: "Split a string on a separator.", Example: `{{ "a,b" | split "," }}`, Output: "[a b]"},
+	"join":           {Summary: "Join strings with a separator.", Example: `{{ "a,b" | split "," | join "-" }}`, Output: "a-b"},
+	"substr":         {Summary: "The bytes from a start to an end index, which may be negative.", Example: `{{ substr 1 3 "abcd" }}`, Output: "bc"},
+	"lower":          {Summary: "A string in lowercase.", Example: `{{ lower "AB" }}`, Output: "ab"},
+	"upper":          {Summary: "A string in uppercase.", Example: `{{ upper "ab" }}`, Output: "AB"},
+	"title":          {Summary: "Capitalize each word.", Example: `{{ title "hello world" }}`, Output: "Hello World"},
+	"initcap":        {Summary: "Lowercase a string, then capitalize each word.", Example: `{{ initcap "HELLO world" }}`, Output: "Hello World"},
+	"replace":        {Summary: "Replace up to a number of occurrences, or all if negative, with the subject first.", Example: `{{ replace "a-b-c" "-" "." -1 }}`, Output: "a.b.c"},
+	"replace_":       {Summary: "Replace up to a number of occurrences, or all if negative.", Example: `{{ "a-b-c" | replace_ 1 "-" "." }}`, Output: "a.b-c"},
+	"trim":           {Summary: "Remove the characters of a cutset from both ends, with the subject first.", Example: `{{ trim "__a__" "_" }}`, Output: "a"},
+	"trim_":          {Summary: "Remove the characters of a cutset from both ends.", Example: `{{ "__a__" | trim_ "_" }}`, Output: "a"},
+	"trim_left":      {Summary: "Remove the characters of a cutset from the start, with the subject first.", Example: `{{ trim_left "__a__" "_" }}`, Output: "a__"},
+	"trim_left_":     {Summary: "Remove the characters of a cutset from the start.", Example: `{{ "__a__" | trim_left_ "_" }}`, Output: "a__"},
+	"trim_right":     {Summary: "Remove the characters of a cutset from the end, with the subject first.", Example: `{{ trim_right "__a__" "_" }}`, Output: "__a"},
+	"trim_right_":    {Summary: "Remove the characters of a cutset from the end.", Example: `{{ "__a__" | trim_right_ "_" }}`, Output: "__a"},
+	"basename":       {Summary: "The last element of a path without any of the extensions.", Example: `{{ basename "a/b.tar" "tar" }}`, Output: "b"},
+	"basename_":      {Summary: "The last element of a path without an extension.", Example: `{{ "a/b.tar" | basename_ "tar" }}`, Output: "b"},
+	"dirname":        {Summary: "All but the last element of a path.", Example: `{{ dirname "a/b/c" }}`, Output: "a/b"},
+	"ext":            {Summary: "The extension of a path.", Example: `{{ ext "a/b.txt" }}`, Output: ".txt"},
+	"path_join":      {Summary: "Join path elements.", Example: `{{ path_join "a" "b" }}`, Output: "a/b"},
+	"rel":            {Summary: "The second path relative to the first.", Example: `{{ rel "a" "a/b/c" }}`, Output: "b/c"},
+	"abs":            {Summary: "An absolute path.", Example: `{{ abs "." }}`},
+	"clean":          {Summary: "The shortest equivalent path.", Example: `{{ clean "a//b/../c" }}`, Output: "a/c"},
+	"glob":           {Summary: "The paths that match a pattern. See WithRoot.", Example: `{{ glob "*.go" }}`},
+	"match":          {Summary: "Whether a name matches a shell pattern.", Example: `{{ match "*.go" "a.go" }}`, Output: "true"},
+	"split_list":     {Summary: "Split a PATH-like list.", Example: `{{ split_list "a:b" }}`},
+	"to_slash":       {Summary: "A path with slashes.", Example: `{{ to_slash "a/b" }}`, Output: "a/b"},
+	"to_backslash":   {Summary: "A path with backslashes.", Example: `{{ to_backslash "a/b" }}`, Output: `a\b`},
+	"to_json":        {Summary: "Compact JSON.", Example: `{{ to_json (split "," "a,b") }}`, Output: `["a","b"]`},
+	"to_pretty_json": {Summary: "JSON indented by two spaces.", Example: `{{ to_pretty_json 1 }}`, Output: "1"},
+	"from_json":      {Summary: "Decode JSON.", Example: `{{ (from_json "{\"a\": 1}").a }}`, Output: "1"},
+	"to_yaml":        {Summary: "YAML without the trailing newline.", Example: `{{ to_yaml (split "," "a,b") }}`, Output: "- a\n- b"},
+	"from_yaml":      {Summary: "Decode YAML.", Example: `{{ (from_yaml "a: 1").a }}`, Output: "1"},
+	"to_toml":        {Summary: "TOML, of a map or struct, without the trailing newline.", Example: `{{ to_toml (from_yaml "a: 1") }}`, Output: "a = 1"},
+	"from_toml":      {Summary: "Decode TOML.", Example: `{{ (from_toml "a = 1").a }}`, Output: "1"},
+
+	"md5":              {Summary: "The hex MD5 of a string.", Example: `{{ md5 "a" }}`, Output: "0cc175b9c0f1b6a831c399e269772661"},
+	"sha1":             {Summary: "The hex SHA-1 of a string.", Example: `{{ sha1 "a" }}`, Output: "86f7e437faa5a7fce15d1ddcb9eaeaea377667b8"},
+	"sha256":           {Summary: "The hex SHA-256 of a string.", Example: `{{ sha256 "a" }}`, Output: "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"},
+	"sha512":           {Summary: "The hex SHA-512 of a string.", Example: `{{ sha512 "a" | short_hash 8 }}`},
+	"crc32":            {Summary: "The hex IEEE CRC-32 of a string.", Example: `{{ crc32 "a" }}`, Output: "e8b7be43"},
+	"fnv":              {Summary: "The hex 64-bit FNV-1a of a string.", Example: `{{ fnv "a" }}`, Output: "af63dc4c8601ec8c"},
+	"xxhash":           {Summary: "The hex 64-bit xxHash of a string.", Example: `{{ xxhash "a" }}`},
+	"short_hash":       {Summary: "The first hex digits of the SHA-256 of a string.", Example: `{{ short_hash 7 "a" }}`, Output: "ca97811"},
+	"hex":              {Summary: "Hex encode a string.", Example: `{{ hex "a" }}`, Output: "61"},
+	"hex_decode":       {Summary: "Decode a hex string.", Example: `{{ hex_decode "61" }}`, Output: "a"},
+	"base32":           {Summary: "Base32 encode a string.", Example: `{{ base32 "a" }}`, Output: "ME======"},
+	"base32_decode":    {Summary: "Decode a base32 string.", Example: `{{ base32_decode "ME======" }}`, Output: "a"},
+	"base64":           {Summary: "Base64 encode a string.", Example: `{{ base64 "a" }}`, Output: "YQ=="},
+	"base64_decode":    {Summary: "Decode a base64 string.", Example: `{{ base64_decode "YQ==" }}`, Output: "a"},
+	"base64url":        {Summary: "Unpadded, URL-safe base64 encode a string.", Example: `{{ base64url "a" }}`, Output: "YQ"},
+	"base64url_decode": {Summary: "Decode an unpadded, URL-safe base64 string.", Example: `{{ base64url_decode "YQ" }}`, Output: "a"},
+
+	"safe_html": {Summary: "Trusted HTML that html/template does not escape.", Example: `{{ safe_html "<b>a</b>" }}`, Output: "<b>a</b>"},
+	"safe_attr": {Summary: "A trusted HTML attribute and value that html/template does not escape.", Example: `{{ safe_attr "dir=\"ltr\"" }}`, Output: `dir="ltr"`},
+	"safe_url":  {Summary: "A trusted URL that html/template does not filter.", Example: `{{ safe_url "mailto:a@example.com" }}`, Output: "mailto:a@example.com"},
+	"safe_js":   {Summary: "Trusted JavaScript that html/template does not escape.", Example: `{{ safe_js "a && b" }}`, Output: "a && b"},
+	"safe_css":  {Summary: "Trusted CSS that html/template does not filter.", Example: `{{ safe_css "color: red" }}`, Output: "color: red"},
+}
@@ -0,0 +1,93 @@
+package funcmap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/gomatic/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// The data of the examples.
+var exampleData = map[string]interface{}{
+	"numbers":   []int{1, 2},
+	"map":       map[string]interface{}{"b": 2, "a": 1},
+	"people":    []map[string]string{{"name": "b"}, {"name": "a"}},
+	"services":  []map[string]string{{"kind": "web"}, {"kind": "db"}},
+	"defaults":  map[string]interface{}{"a": 1},
+	"overrides": map[string]interface{}{"a": 2},
+}
+
+//
+func TestDocExamples(t *testing.T) {
+	env := WithEnvMap(map[string]string{"HOME": "/home/gopher", "APP_NAME": "api", "PORT": ""})
+	funcs := New(WithV1Map(), WithCryptoMap(), WithSafeTypes(), env, WithSeed(1), WithClock(clock.Playground.MustTime()))
+	data := exampleData
+	for name, d := range docs {
+		if d.Example == "" {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := template.New(name).Funcs(funcs).Parse(d.Example)
+			if !assert.NoError(t, err) {
+				return
+			}
+			b := bytes.Buffer{}
+			if !assert.NoError(t, tmpl.Execute(&b, data)) || d.Output == "" {
+				return
+			}
+			assert.Equal(t, d.Output, b.String())
+		})
+	}
+}
+
+//
+func TestDescribe(t *testing.T) {
+	for _, m := range []template.FuncMap{v1Map, v4Map, cryptoMap, htmlMap, New(WithV2Map()), New(WithV3Map())} {
+		for name := range m {
+			_, ok := Describe(m, name)
+			assert.True(t, ok, name)
+		}
+	}
+
+	d, _ := Describe(v1Map, "toJSON")
+	assert.Equal(t, "to_json", d.Name)
+	d, _ = Describe(v1Map, "trimLeft_")
+	assert.Equal(t, "trim_left_", d.Name)
+	d, _ = Describe(v4Map, "trim")
+	assert.Equal(t, "trim_", d.Name)
+	d, _ = Describe(v1Map, "identifier")
+	assert.Equal(t, "identifier", d.Name)
+	d, _ = Describe(v1Map, "environment")
+	assert.Equal(t, "env", d.Name)
+	d, _ = Describe(New(WithV3Map()), "camelcase")
+	assert.Equal(t, Doc{Name: "camelcase", Summary: "A sprig function; see https://masterminds.github.io/sprig/."}, d)
+	d, _ = Describe(New(WithV2Map()), "upper")
+	assert.Equal(t, "upper", d.Name)
+	assert.Equal(t, "A string in uppercase.", d.Summary)
+	_, ok := Describe(v1Map, "nope")
+	assert.False(t, ok)
+	_, ok = Describe(template.FuncMap{"mine": func() string { return "" }}, "mine")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"toJSON"}, Aliases(v1Map, "to_json"))
+	assert.Equal(t, []string{"IPMath", "ipMath"}, Aliases(template.FuncMap{"ip_math": IPMath, "IPMath": IPMath, "ipMath": IPMath, "ip_add": IPMath}, "ip_math"))
+	assert.Empty(t, Aliases(v1Map, "identifier"))
+	assert.Nil(t, Aliases(v1Map, "nope"))
+}
+
+//
+func TestCompare(t *testing.T) {
+	got := Compare(
+		template.FuncMap{"upper": strings.ToUpper, "trim": ReTrim, "gone": Rand},
+		template.FuncMap{"upper": strings.ToUpper, "trim": ReTrimLeft, "new": Rand},
+	)
+	assert.Equal(t, []Change{
+		{Name: "gone", Kind: "removed"},
+		{Name: "new", Kind: "added"},
+		{Name: "trim", Kind: "changed", Reason: "is a different func(string, string) string; check the order of its arguments"},
+	}, got)
+	assert.Empty(t, Compare(v1Map, v1Map))
+}
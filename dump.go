@@ -0,0 +1,125 @@
+package funcmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// Indented, cycle-safe dump of each value.
+func Dump(any ...interface{}) string {
+	return DumpDepth(0, any...)
+}
+
+// Like Dump but descends at most `depth` levels. Zero is unlimited.
+func DumpDepth(depth int, any ...interface{}) string {
+	c := spew.ConfigState{
+		Indent:                  "  ",
+		MaxDepth:                depth,
+		SortKeys:                true,
+		DisablePointerAddresses: true,
+		DisableCapacities:       true,
+	}
+	return strings.TrimSuffix(c.Sdump(any...), "\n")
+}
+
+// JSON-style dump of `v`. Cycles and values beyond `depth` levels, when non-zero, are replaced by markers.
+func DumpJSON(depth int, v interface{}) string {
+	b := bytes.Buffer{}
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", "  ")
+	if err := e.Encode(dumpable(reflect.ValueOf(v), depth, map[uintptr]bool{})); err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Like Dump but writes the dump to `w` so it stays out of the rendered output.
+func DumpTo(w io.Writer) func(...interface{}) string {
+	lock := sync.Mutex{}
+	return func(any ...interface{}) string {
+		lock.Lock()
+		defer lock.Unlock()
+		fmt.Fprintln(w, Dump(any...))
+		return ""
+	}
+}
+
+func dumpable(v reflect.Value, depth int, seen map[uintptr]bool) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Slice && v.Len() == 0 {
+			return []interface{}{}
+		}
+		p := v.Pointer()
+		if seen[p] {
+			return "<cycle>"
+		}
+		seen[p] = true
+		defer delete(seen, p)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return dumpable(v.Elem(), depth, seen)
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return "<" + v.Type().String() + ">"
+	}
+
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		if depth == 1 {
+			return "<max depth>"
+		}
+		if depth > 1 {
+			depth--
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		return dumpable(v.Elem(), depth, seen)
+	case reflect.Map:
+		m := make(map[string]interface{}, v.Len())
+		for _, k := range v.MapKeys() {
+			m[fmt.Sprint(k)] = dumpable(v.MapIndex(k), depth, seen)
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			s[i] = dumpable(v.Index(i), depth, seen)
+		}
+		return s
+	case reflect.Struct:
+		if v.CanInterface() && v.Type().Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) {
+			return v.Interface()
+		}
+		m := map[string]interface{}{}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			m[t.Field(i).Name] = dumpable(v.Field(i), depth, seen)
+		}
+		return m
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,89 @@
+package funcmap
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dumpNode struct {
+	Name     string
+	Children []*dumpNode
+	Parent   *dumpNode
+	hidden   int
+}
+
+func dumpTree() *dumpNode {
+	root := &dumpNode{Name: "root", hidden: 1}
+	root.Children = []*dumpNode{{Name: "child", Parent: root}}
+	return root
+}
+
+//
+func TestDump(t *testing.T) {
+	got := Dump(map[string]interface{}{"b": []int{1}, "a": "x"})
+	assert.Equal(t, "(map[string]interface {}) (len=2) {\n  (string) (len=1) \"a\": (string) (len=1) \"x\",\n  (string) (len=1) \"b\": ([]int) (len=1) {\n    (int) 1\n  }\n}", got)
+
+	got = Dump(dumpTree())
+	assert.Contains(t, got, "<already shown>")
+
+	got = DumpDepth(1, dumpTree())
+	assert.Contains(t, got, "<max depth reached>")
+	assert.NotContains(t, got, "child")
+}
+
+//
+func TestDumpJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		depth int
+		args  interface{}
+		want  string
+	}{
+		{
+			name: "nil",
+			args: nil,
+			want: "null",
+		},
+		{
+			name: "map",
+			args: map[int][]string{2: {"b"}, 1: nil},
+			want: "{\n  \"1\": null,\n  \"2\": [\n    \"b\"\n  ]\n}",
+		},
+		{
+			name: "cycle",
+			args: dumpTree(),
+			want: "{\n  \"Children\": [\n    {\n      \"Children\": null,\n      \"Name\": \"child\",\n      \"Parent\": \"<cycle>\"\n    }\n  ],\n  \"Name\": \"root\",\n  \"Parent\": null\n}",
+		},
+		{
+			name:  "depth",
+			depth: 2,
+			args:  dumpTree(),
+			want:  "{\n  \"Children\": \"<max depth>\",\n  \"Name\": \"root\",\n  \"Parent\": null\n}",
+		},
+		{
+			name: "func",
+			args: []interface{}{Add},
+			want: "[\n  \"<func(int64, int64) int64>\"\n]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DumpJSON(tt.depth, tt.args); got != tt.want {
+				t.Errorf("DumpJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestDebugDump(t *testing.T) {
+	w := bytes.Buffer{}
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map(), WithDebugWriter(&w))).Parse(`a{{ debug_dump . }}b`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, []int{1}))
+	assert.Equal(t, "ab", b.String())
+	assert.Equal(t, "([]int) (len=1) {\n  (int) 1\n}\n", w.String())
+}
@@ -0,0 +1,73 @@
+package funcmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Encode `v` as compact JSON. Map keys are sorted.
+func ToJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Encode `v` as JSON indented by two spaces. Map keys are sorted.
+func ToPrettyJSON(v interface{}) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+//
+func FromJSON(s string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Encode `v` as YAML without the trailing newline. Map keys are sorted.
+func ToYAML(v interface{}) (string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
+
+//
+func FromYAML(s string) (interface{}, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Encode `v`, which must be a map or struct, as TOML without the trailing newline. Map keys are sorted.
+func ToTOML(v interface{}) (string, error) {
+	b := bytes.Buffer{}
+	if err := toml.NewEncoder(&b).Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+//
+func FromTOML(s string) (map[string]interface{}, error) {
+	v := map[string]interface{}{}
+	if _, err := toml.Decode(s, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
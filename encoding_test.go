@@ -0,0 +1,136 @@
+package funcmap
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestToJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "nil",
+			args: nil,
+			want: "null",
+		},
+		{
+			name: "sorted keys",
+			args: map[string]interface{}{"b": 2, "a": []string{"x", "y"}, "c": map[string]int{"z": 1, "y": 0}},
+			want: `{"a":["x","y"],"b":2,"c":{"y":0,"z":1}}`,
+		},
+		{
+			name:    "unsupported",
+			args:    func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToJSON(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ToJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ToJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestToPrettyJSON(t *testing.T) {
+	got, err := ToPrettyJSON(map[string]interface{}{"b": 1, "a": []int{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": [\n    1\n  ],\n  \"b\": 1\n}", got)
+}
+
+//
+func TestFromJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "object",
+			args: `{"a":[1,"x"],"b":{"c":true}}`,
+			want: map[string]interface{}{"a": []interface{}{float64(1), "x"}, "b": map[string]interface{}{"c": true}},
+		},
+		{
+			name: "scalar",
+			args: `"x"`,
+			want: "x",
+		},
+		{
+			name:    "invalid",
+			args:    `{"a":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromJSON(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+//
+func TestYAML(t *testing.T) {
+	in := map[string]interface{}{"b": []interface{}{"x", "y"}, "a": map[string]interface{}{"d": 1, "c": "z"}}
+	got, err := ToYAML(in)
+	assert.NoError(t, err)
+	assert.Equal(t, "a:\n    c: z\n    d: 1\nb:\n    - x\n    - \"y\"", got)
+
+	back, err := FromYAML(got)
+	assert.NoError(t, err)
+	assert.Equal(t, in, back)
+
+	_, err = FromYAML("a: [")
+	assert.Error(t, err)
+}
+
+//
+func TestTOML(t *testing.T) {
+	in := map[string]interface{}{"b": "x", "a": int64(1), "t": map[string]interface{}{"k": true}}
+	got, err := ToTOML(in)
+	assert.NoError(t, err)
+	assert.Equal(t, "a = 1\nb = \"x\"\n\n[t]\n  k = true", got)
+
+	back, err := FromTOML(got)
+	assert.NoError(t, err)
+	assert.Equal(t, in, back)
+
+	_, err = ToTOML("scalar")
+	assert.Error(t, err)
+
+	_, err = FromTOML("a = ")
+	assert.Error(t, err)
+}
+
+//
+func TestEncodingTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map())).Parse(
+		`{{ range $k, $v := from_json .json }}{{ $k }}={{ $v }};{{ end }}{{ .data | to_json }}`))
+	b := bytes.Buffer{}
+	err := tmpl.Execute(&b, map[string]interface{}{
+		"json": `{"y":1,"x":2}`,
+		"data": map[string]int{"b": 1, "a": 2},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `x=2;y=1;{"a":2,"b":1}`, b.String())
+}
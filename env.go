@@ -0,0 +1,129 @@
+package funcmap
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Environment functions that read variables through `lookup` instead of os.LookupEnv.
+// `names` lists the available variables for `env_prefix`; if nil, `env_prefix` is an error.
+func Environ(lookup func(string) (string, bool), names func() []string) template.FuncMap {
+	return environ{lookup: lookup, names: names}.funcMap()
+}
+
+//
+func EnvDefault(def, n string) string { return osEnviron.envDefault(def, n) }
+
+//
+func EnvRequired(n string) (string, error) { return osEnviron.envRequired(n) }
+
+//
+func EnvBool(n string) (bool, error) { return osEnviron.envBool(n) }
+
+//
+func EnvInt(n string) (int64, error) { return osEnviron.envInt(n) }
+
+//
+func EnvPrefix(prefix string) (map[string]string, error) { return osEnviron.envPrefix(prefix) }
+
+var osEnviron = environ{
+	lookup: os.LookupEnv,
+	names: func() []string {
+		env := os.Environ()
+		names := make([]string, len(env))
+		for i, e := range env {
+			names[i] = strings.SplitN(e, "=", 2)[0]
+		}
+		return names
+	},
+}
+
+type environ struct {
+	lookup func(string) (string, bool)
+	names  func() []string
+}
+
+func (e environ) funcMap() template.FuncMap {
+	return template.FuncMap{
+		"environment":  e.env,
+		"env":          e.env,
+		"env_default":  e.envDefault,
+		"envDefault":   e.envDefault,
+		"env_required": e.envRequired,
+		"envRequired":  e.envRequired,
+		"env_bool":     e.envBool,
+		"envBool":      e.envBool,
+		"env_int":      e.envInt,
+		"envInt":       e.envInt,
+		"env_prefix":   e.envPrefix,
+		"envPrefix":    e.envPrefix,
+	}
+}
+
+func (e environ) env(n string) string {
+	v, _ := e.lookup(n)
+	return v
+}
+
+// `def` if `n` is unset or empty.
+func (e environ) envDefault(def, n string) string {
+	if v, _ := e.lookup(n); v != "" {
+		return v
+	}
+	return def
+}
+
+// An error if `n` is unset.
+func (e environ) envRequired(n string) (string, error) {
+	v, exists := e.lookup(n)
+	if !exists {
+		return "", fmt.Errorf("%s: environment variable not set", n)
+	}
+	return v, nil
+}
+
+// `false` if `n` is unset or empty, otherwise as parsed by strconv.ParseBool.
+func (e environ) envBool(n string) (bool, error) {
+	v, _ := e.lookup(n)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("%s: %v", n, err)
+	}
+	return b, nil
+}
+
+// `0` if `n` is unset or empty, otherwise as parsed by strconv.ParseInt with base prefixes.
+func (e environ) envInt(n string) (int64, error) {
+	v, _ := e.lookup(n)
+	if v == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(v, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", n, err)
+	}
+	return i, nil
+}
+
+// The variables whose names start with `prefix`.
+func (e environ) envPrefix(prefix string) (map[string]string, error) {
+	if e.names == nil {
+		return nil, fmt.Errorf("%s: environment cannot be listed", prefix)
+	}
+	vars := map[string]string{}
+	for _, n := range e.names() {
+		if !strings.HasPrefix(n, prefix) {
+			continue
+		}
+		if v, exists := e.lookup(n); exists {
+			vars[n] = v
+		}
+	}
+	return vars, nil
+}
@@ -0,0 +1,149 @@
+package funcmap
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testEnviron = environ{
+	lookup: func(n string) (string, bool) {
+		v, exists := map[string]string{
+			"EMPTY":    "",
+			"NAME":     "value",
+			"FLAG":     "true",
+			"BAD_FLAG": "maybe",
+			"NUM":      "0x10",
+			"BAD_NUM":  "ten",
+			"APP_A":    "a",
+			"APP_B":    "b",
+		}[n]
+		return v, exists
+	},
+	names: func() []string {
+		return []string{"EMPTY", "NAME", "FLAG", "BAD_FLAG", "NUM", "BAD_NUM", "APP_A", "APP_B"}
+	},
+}
+
+//
+func TestEnvDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		def  string
+		want string
+	}{
+		{name: "NAME", def: "default", want: "value"},
+		{name: "EMPTY", def: "default", want: "default"},
+		{name: "MISSING", def: "default", want: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testEnviron.envDefault(tt.def, tt.name); got != tt.want {
+				t.Errorf("envDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestEnvRequired(t *testing.T) {
+	v, err := testEnviron.envRequired("EMPTY")
+	assert.NoError(t, err)
+	assert.Equal(t, "", v)
+	_, err = testEnviron.envRequired("MISSING")
+	assert.EqualError(t, err, "MISSING: environment variable not set")
+}
+
+//
+func TestEnvBool(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "FLAG", want: true},
+		{name: "EMPTY", want: false},
+		{name: "MISSING", want: false},
+		{name: "BAD_FLAG", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testEnviron.envBool(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("envBool() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("envBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestEnvInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+	}{
+		{name: "NUM", want: 16},
+		{name: "MISSING", want: 0},
+		{name: "BAD_NUM", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testEnviron.envInt(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("envInt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("envInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestEnvPrefix(t *testing.T) {
+	got, err := testEnviron.envPrefix("APP_")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"APP_A": "a", "APP_B": "b"}, got)
+
+	_, err = environ{lookup: testEnviron.lookup}.envPrefix("APP_")
+	assert.Error(t, err)
+
+	os.Setenv("FUNCMAP_TEST_PREFIX", "x")
+	defer os.Unsetenv("FUNCMAP_TEST_PREFIX")
+	got, err = EnvPrefix("FUNCMAP_TEST_")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"FUNCMAP_TEST_PREFIX": "x"}, got)
+}
+
+//
+func TestWithEnv(t *testing.T) {
+	os.Setenv("FUNCMAP_TEST_HIDDEN", "leaked")
+	defer os.Unsetenv("FUNCMAP_TEST_HIDDEN")
+
+	render := func(options ...Optional) (string, error) {
+		tmpl := template.Must(template.New("").Funcs(New(options...)).Parse(
+			`{{ env "FUNCMAP_TEST_HIDDEN" }}|{{ env_default "d" "FUNCMAP_TEST_HOST" }}|{{ env_required "FUNCMAP_TEST_PORT" }}`))
+		b := bytes.Buffer{}
+		err := tmpl.Execute(&b, nil)
+		return b.String(), err
+	}
+
+	got, err := render(WithV1Map(), WithEnvMap(map[string]string{"FUNCMAP_TEST_HOST": "h", "FUNCMAP_TEST_PORT": "80"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "|h|80", got)
+
+	_, err = render(WithV1Map(), WithEnv(func(string) (string, bool) { return "", false }))
+	assert.Error(t, err)
+
+	_, err = render(WithV1Map())
+	assert.Error(t, err)
+}
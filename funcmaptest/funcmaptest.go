@@ -0,0 +1,155 @@
+// Package funcmaptest tests templates that use funcmap against golden files.
+//
+// Each `name.tmpl` in a directory is rendered with the data of `name.json`, `name.yaml`,
+// `name.yml` or `name.toml`, if there is one, and compared with `name.golden`. Templates named
+// with a leading `_` are partials: they are parsed with every other template but not rendered
+// themselves. Run the tests with -update to write the golden files instead.
+//
+//	func TestTemplates(t *testing.T) {
+//		funcmaptest.Run(t, "testdata", funcmap.WithV4Map())
+//	}
+//
+// This package defines the -update flag, so test packages that use it should not define their own.
+package funcmaptest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/gomatic/clock"
+	"github.com/gomatic/funcmap"
+)
+
+// The time of `now` and `started`, and of the time-ordered IDs.
+var Time = clock.Playground.MustTime()()
+
+// The seed of `rand` and of the random IDs.
+const Seed = 1
+
+func init() {
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "write the golden files of funcmaptest instead of comparing with them")
+	}
+}
+
+func updating() bool {
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+// A map made with `options` and then a fixed clock, a fixed seed and sequences of its own, so that
+// it renders the same way every time.
+func Funcs(options ...funcmap.Optional) template.FuncMap {
+	return funcmap.New(append(append([]funcmap.Optional{}, options...),
+		funcmap.WithClock(func() time.Time { return Time }),
+		funcmap.WithSeed(Seed),
+		funcmap.WithSequencers(),
+	)...)
+}
+
+// Render each template in `dir` with Funcs(options...) and compare it with its golden file, as a
+// subtest named after the template.
+func Run(t *testing.T, dir string, options ...funcmap.Optional) {
+	t.Helper()
+	names, partials, err := templates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatalf("no templates in %s", dir)
+	}
+	for _, name := range names {
+		name := name
+		t.Run(strings.TrimSuffix(name, ".tmpl"), func(t *testing.T) {
+			got, err := Render(dir, name, partials, options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join(dir, strings.TrimSuffix(name, ".tmpl")+".golden")
+			if updating() {
+				if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v; run with -update to write it", err)
+			}
+			if got != string(want) {
+				t.Errorf("%s does not match %s\ngot:\n%s\nwant:\n%s", name, golden, got, want)
+			}
+		})
+	}
+}
+
+// Render the template `name` in `dir`, with the `partials`, its data and Funcs(options...).
+func Render(dir, name string, partials []string, options ...funcmap.Optional) (string, error) {
+	tmpl := template.New(name).Funcs(Funcs(options...))
+	for _, n := range append([]string{name}, partials...) {
+		text, err := ioutil.ReadFile(filepath.Join(dir, n))
+		if err != nil {
+			return "", err
+		}
+		if _, err := tmpl.New(n).Parse(string(text)); err != nil {
+			return "", err
+		}
+	}
+	data, err := Data(dir, strings.TrimSuffix(name, ".tmpl"))
+	if err != nil {
+		return "", err
+	}
+	b := bytes.Buffer{}
+	if err := tmpl.ExecuteTemplate(&b, name, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// The data of the fixture `base` in `dir`, or nil if it has none.
+func Data(dir, base string) (interface{}, error) {
+	for _, ext := range []string{".json", ".yaml", ".yml", ".toml"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, base+ext))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch ext {
+		case ".json":
+			return funcmap.FromJSON(string(b))
+		case ".toml":
+			return funcmap.FromTOML(string(b))
+		}
+		return funcmap.FromYAML(string(b))
+	}
+	return nil, nil
+}
+
+// The templates and the partials in `dir`, sorted.
+func templates(dir string) ([]string, []string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", dir, err)
+	}
+	sort.Strings(paths)
+	names, partials := []string{}, []string{}
+	for _, p := range paths {
+		if n := filepath.Base(p); strings.HasPrefix(n, "_") {
+			partials = append(partials, n)
+		} else {
+			names = append(names, n)
+		}
+	}
+	return names, partials, nil
+}
@@ -0,0 +1,50 @@
+package funcmaptest
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gomatic/funcmap"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestRun(t *testing.T) {
+	Run(t, "testdata", funcmap.WithV1Map())
+}
+
+//
+func TestUpdate(t *testing.T) {
+	dir := t.TempDir()
+	for _, n := range []string{"page.tmpl", "page.yaml", "_header.tmpl"} {
+		b, err := ioutil.ReadFile(filepath.Join("testdata", n))
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, n), b, 0644))
+	}
+	assert.NoError(t, flag.Set("update", "true"))
+	defer flag.Set("update", "false")
+	Run(t, dir, funcmap.WithV1Map())
+
+	got, err := ioutil.ReadFile(filepath.Join(dir, "page.golden"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Page\nAPI 1\n", string(got))
+	_, err = os.Stat(filepath.Join(dir, "_header.golden"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+//
+func TestRender(t *testing.T) {
+	got, err := Render("testdata", "ids.tmpl", nil, funcmap.WithV1Map())
+	assert.NoError(t, err)
+	again, err := Render("testdata", "ids.tmpl", nil, funcmap.WithV1Map())
+	assert.NoError(t, err)
+	assert.Equal(t, got, again)
+
+	_, err = Render("testdata", "page.tmpl", nil, funcmap.WithV1Map())
+	assert.EqualError(t, err, `template: page.tmpl:1:12: executing "page.tmpl" at <{{template "_header.tmpl" .}}>: template "_header.tmpl" not defined`)
+	_, err = Render("testdata", "nope.tmpl", nil)
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package funcmap
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -21,6 +22,15 @@ type opt struct {
 	maps               []template.FuncMap
 	rightmostOverrides bool
 	timeFunc           clock.TimeFunction
+	root               string
+	env                *environ
+	tracer             Tracer
+	debugging          bool
+	debugWriter        io.Writer
+	middleware         []Middleware
+	recovering         bool
+	seed               *int64
+	sequencers         bool
 }
 
 //
@@ -55,7 +65,7 @@ func WithV1Map() Optional {
 
 //
 func WithV2Map() Optional {
-	return WithMaps(v1Map, sprig.GenericFuncMap())
+	return WithMaps(v2Map, sprig.GenericFuncMap())
 }
 
 //
@@ -63,7 +73,21 @@ func WithV3Map() Optional {
 	return WithMaps(sprig.GenericFuncMap(), v1Map)
 }
 
-//
+// Like WithV1Map but every function takes its subject last, so that it can be piped into. The
+// `replace`, `trim`, `trim_left`, `trim_right` and `basename` functions are the `_` variants. See
+// Lint.
+func WithV4Map() Optional {
+	return WithMaps(v4Map)
+}
+
+// Like WithV4Map but without the functions that read the environment, the file system or the
+// command line, sleep, or toggle debugging or count sequences for every template. Add
+// WithSequencers for `next` and `keynext` of the map's own.
+func WithSafeMap() Optional {
+	return WithMaps(safeMap)
+}
+
+// Read the time for `now`, `started` and the time-ordered IDs from `timeFunc`.
 func WithClock(timeFunc clock.TimeFunction) Optional {
 	return func(o *opt) {
 		if timeFunc == nil {
@@ -73,6 +97,107 @@ func WithClock(timeFunc clock.TimeFunction) Optional {
 	}
 }
 
+// Bind the `read_file`, `file_exists`, `list_dir` and `glob` functions to `root`. See Rooted.
+func WithRoot(root string) Optional {
+	return func(o *opt) {
+		if root == "" {
+			return
+		}
+		o.root = root
+	}
+}
+
+// Read environment variables through `lookup` instead of os.LookupEnv. `env_prefix` is an error.
+func WithEnv(lookup func(string) (string, bool)) Optional {
+	return func(o *opt) {
+		if lookup == nil {
+			return
+		}
+		o.env = &environ{lookup: lookup}
+	}
+}
+
+// Read environment variables from `env` instead of the process environment.
+func WithEnvMap(env map[string]string) Optional {
+	return func(o *opt) {
+		if env == nil {
+			return
+		}
+		o.env = &environ{
+			lookup: func(n string) (string, bool) {
+				v, exists := env[n]
+				return v, exists
+			},
+			names: func() []string {
+				names := make([]string, 0, len(env))
+				for n := range env {
+					names = append(names, n)
+				}
+				return names
+			},
+		}
+	}
+}
+
+// Report function calls to `tracer` while debugging is enabled. The resulting map gets its own
+// `debugging` and `debug_toggle` functions to control it.
+func WithTrace(tracer Tracer) Optional {
+	return func(o *opt) {
+		if tracer == nil {
+			return
+		}
+		o.tracer = tracer
+	}
+}
+
+// Start with debugging enabled. Without WithTrace, function calls are traced to the debug writer,
+// or to stderr if there is none. Without this, `debug_toggle` turns tracing on.
+func WithDebugging() Optional {
+	return func(o *opt) {
+		o.debugging = true
+	}
+}
+
+// Write `debug` and `debug_dump` output to `w` instead of the rendered output, and, while the map's
+// own `debug_toggle` has debugging enabled, a line for each function call. See WithDebugging.
+func WithDebugWriter(w io.Writer) Optional {
+	return func(o *opt) {
+		if w == nil {
+			return
+		}
+		o.debugWriter = w
+	}
+}
+
+// Wrap every function in the resulting map with `middleware`, the first being outermost.
+func WithMiddleware(middleware ...Middleware) Optional {
+	return func(o *opt) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// Return panics from any function in the resulting map as errors. See Recovering.
+func WithRecover() Optional {
+	return func(o *opt) {
+		o.recovering = true
+	}
+}
+
+// Seed `rand`, the `[R]` of `ip_math`, and the random ID functions so that they are
+// reproducible. See Identifiers.
+func WithSeed(seed int64) Optional {
+	return func(o *opt) {
+		o.seed = &seed
+	}
+}
+
+// Give the resulting map its own `next` and `keynext` sequences instead of the ones every map shares.
+func WithSequencers() Optional {
+	return func(o *opt) {
+		o.sequencers = true
+	}
+}
+
 //
 func New(options ...Optional) template.FuncMap {
 	opts := opt{
@@ -99,7 +224,96 @@ func New(options ...Optional) template.FuncMap {
 	}
 
 	if opts.timeFunc != nil {
-		fm["now"] = opts.timeFunc
+		timeFunc := opts.timeFunc
+		fm["now"] = timeFunc
+		if sameFunc(fm["started"], Starter) {
+			fm["started"] = func() func() time.Time {
+				started := timeFunc()
+				return func() time.Time { return started }
+			}
+		}
+	}
+
+	if opts.sequencers {
+		keySequencer := KeySequencer()
+		fm["next"] = Sequencer()
+		fm["keynext"] = keySequencer
+		fm["keyNext"] = keySequencer
+	}
+
+	if opts.seed != nil || opts.timeFunc != nil {
+		g := defaultIDs
+		if opts.seed != nil {
+			r := &lockedRand{r: rand.New(rand.NewSource(*opts.seed))}
+			g.random = r
+			if sameFunc(fm["rand"], Rand) {
+				fm["rand"] = r.Int63
+			}
+			for _, k := range []string{"ip_math", "IPMath"} {
+				if sameFunc(fm[k], IPMath) {
+					fm[k] = r.ipMath
+				}
+			}
+		}
+		if opts.timeFunc != nil {
+			g.now = opts.timeFunc
+		}
+		// Only the default functions, so that other maps, and the caller's own, are left alone.
+		for k, f := range g.funcMap() {
+			if sameFunc(fm[k], v1Map[k]) {
+				fm[k] = f
+			}
+		}
+	}
+
+	if opts.root != "" {
+		for k, f := range Rooted(opts.root) {
+			fm[k] = f
+		}
+	}
+
+	if opts.env != nil {
+		for k, f := range opts.env.funcMap() {
+			fm[k] = f
+		}
+	}
+
+	if opts.debugWriter != nil {
+		fm["debug"] = DebugTo(opts.debugWriter)
+		fm["debug_dump"] = DumpTo(opts.debugWriter)
+		fm["debugDump"] = fm["debug_dump"]
+	}
+
+	middleware := opts.middleware
+	if opts.recovering {
+		middleware = append([]Middleware{Recovering()}, middleware...)
+	}
+	tracer := opts.tracer
+	if tracer == nil && (opts.debugging || opts.debugWriter != nil) {
+		w := opts.debugWriter
+		if w == nil {
+			w = os.Stderr
+		}
+		tracer = WriterTracer(w)
+	}
+	get, toggle := Debugger()
+	if tracer != nil {
+		if opts.debugging {
+			toggle()
+		}
+		middleware = append(middleware, Tracing(get, tracer))
+	}
+
+	if len(middleware) != 0 {
+		for k, f := range fm {
+			fm[k] = Wrap(k, f, middleware...)
+		}
+	}
+
+	if tracer != nil {
+		fm["debugging"] = get
+		fm["debug_toggle"] = toggle
+		fm["debugToggle"] = toggle
 	}
 
 	return fm
@@ -110,97 +324,268 @@ func init() {
 
 	keySequencer := KeySequencer()
 	v1Map = template.FuncMap{
-		"debug":        Debug,
-		"debugging":    debugging,
-		"debug_toggle": debugToggle,
-		"debugToggle":  debugToggle,
-		"pause":        Pause,
-		"command_line": CommandLine,
-		"commandLine":  CommandLine,
-		"ip_math":      IPMath,
-		"IPMath":       IPMath,
-		"ip4_inc":      IP4Inc,
-		"IP4Inc":       IP4Inc,
-		"ip4_next":     IP4Next,
-		"IP4Next":      IP4Next,
-		"ip4_prev":     IP4Prev,
-		"IP4Prev":      IP4Prev,
-		"ip4_add":      IP4Add,
-		"IP4Add":       IP4Add,
-		"ip4_join":     IP4Join,
-		"IP4Join":      IP4Join,
-		"ip6_inc":      IP6Inc,
-		"IP6Inc":       IP6Inc,
-		"ip6_next":     IP6Next,
-		"IP6Next":      IP6Next,
-		"ip6_prev":     IP6Prev,
-		"IP6Prev":      IP6Prev,
-		"ip6_add":      IP6Add,
-		"IP6Add":       IP6Add,
-		"ip6_join":     IP6Join,
-		"IP6Join":      IP6Join,
-		"cidr_next":    CIDRNext,
-		"CIDRNext":     CIDRNext,
-		"ip_ints":      IPInts,
-		"IPInts":       IPInts,
-		"ip_split":     IPSplit,
-		"IPSplit":      IPSplit,
-		"to_int":       ToInt,
-		"ToInt":        ToInt,
-		"dec_to_int":   DecToInt,
-		"DecToInt":     DecToInt,
-		"hex_to_int":   HexToInt,
-		"HexToInt":     HexToInt,
-		"from_int":     FromInt,
-		"FromInt":      FromInt,
-		"next":         Sequencer(),
-		"keynext":      keySequencer,
-		"keyNext":      keySequencer,
-		"inc":          Step,
-		"add":          Add,
-		"sub":          Sub,
-		"mul":          Mul,
-		"div":          SafeDiv,
-		"div_":         Div,
-		"mod":          Mod,
-		"rand":         Rand,
-		"identifier":   Cleanse(`^[^[:alpha:]_]+|[^[:alnum:]_]`),
-		"cleanse":      Cleanse(`[^[:alpha:]]`),
-		"cleanser":     Cleanser,
-		"environment":  Environment,
-		"env":          Environment,
-		"now":          time.Now,
-		"started":      Starter,
-		"iindex":       Index,
-		"split":        Split,
-		"join":         Join,
-		"substr":       Substr,
-		"lower":        strings.ToLower,
-		"toLower":      strings.ToLower,
-		"replace":      strings.Replace,
-		"replace_":     ReReplace,
-		"title":        strings.Title,
-		"initcap":      ReInitcap,
-		"trim":         strings.Trim,
-		"trim_":        ReTrim,
-		"trim_left":    strings.TrimLeft,
-		"trimLeft":     strings.TrimLeft,
-		"trim_left_":   ReTrimLeft,
-		"trimLeft_":    ReTrimLeft,
-		"trim_right":   strings.TrimRight,
-		"trimRight":    strings.TrimRight,
-		"trim_right_":  ReTrimRight,
-		"trimRight_":   ReTrimRight,
-		"upper":        strings.ToUpper,
-		"toUpper":      strings.ToUpper,
-		"basename":     Basename,
-		"dirname":      filepath.Dir,
-		"ext":          filepath.Ext,
+		"debug":          Debug,
+		"dump":           Dump,
+		"debug_dump":     Dump,
+		"debugDump":      Dump,
+		"dump_depth":     DumpDepth,
+		"dumpDepth":      DumpDepth,
+		"dump_json":      DumpJSON,
+		"dumpJSON":       DumpJSON,
+		"debugging":      debugging,
+		"debug_toggle":   debugToggle,
+		"debugToggle":    debugToggle,
+		"pause":          Pause,
+		"command_line":   CommandLine,
+		"commandLine":    CommandLine,
+		"ip_math":        IPMath,
+		"IPMath":         IPMath,
+		"ip4_inc":        IP4Inc,
+		"IP4Inc":         IP4Inc,
+		"ip4_next":       IP4Next,
+		"IP4Next":        IP4Next,
+		"ip4_prev":       IP4Prev,
+		"IP4Prev":        IP4Prev,
+		"ip4_add":        IP4Add,
+		"IP4Add":         IP4Add,
+		"ip4_join":       IP4Join,
+		"IP4Join":        IP4Join,
+		"ip6_inc":        IP6Inc,
+		"IP6Inc":         IP6Inc,
+		"ip6_next":       IP6Next,
+		"IP6Next":        IP6Next,
+		"ip6_prev":       IP6Prev,
+		"IP6Prev":        IP6Prev,
+		"ip6_add":        IP6Add,
+		"IP6Add":         IP6Add,
+		"ip6_join":       IP6Join,
+		"IP6Join":        IP6Join,
+		"cidr_next":      CIDRNext,
+		"CIDRNext":       CIDRNext,
+		"ip_ints":        IPInts,
+		"IPInts":         IPInts,
+		"ip_split":       IPSplit,
+		"IPSplit":        IPSplit,
+		"to_int":         ToInt,
+		"ToInt":          ToInt,
+		"dec_to_int":     DecToInt,
+		"DecToInt":       DecToInt,
+		"hex_to_int":     HexToInt,
+		"HexToInt":       HexToInt,
+		"from_int":       FromInt,
+		"FromInt":        FromInt,
+		"to_int_strict":  ToIntStrict,
+		"toIntStrict":    ToIntStrict,
+		"invalid_ints":   InvalidInts,
+		"invalidInts":    InvalidInts,
+		"from_int_base":  FromIntBase,
+		"fromIntBase":    FromIntBase,
+		"parse_int":      ParseInt,
+		"parseInt":       ParseInt,
+		"parse_uint":     ParseUint,
+		"parseUint":      ParseUint,
+		"parse_int128":   ParseInt128,
+		"parseInt128":    ParseInt128,
+		"parse_uint128":  ParseUint128,
+		"parseUint128":   ParseUint128,
+		"parse_uints":    ParseUints,
+		"parseUints":     ParseUints,
+		"parse_int128s":  ParseInt128s,
+		"parseInt128s":   ParseInt128s,
+		"parse_uint128s": ParseUint128s,
+		"parseUint128s":  ParseUint128s,
+		"format_int":     FormatInt,
+		"formatInt":      FormatInt,
+		"format_number":  FormatNumber,
+		"formatNumber":   FormatNumber,
+		"to_base":        ToBase,
+		"toBase":         ToBase,
+		"to_binary":      ToBinary,
+		"toBinary":       ToBinary,
+		"to_octal":       ToOctal,
+		"toOctal":        ToOctal,
+		"to_hex":         ToHex,
+		"toHex":          ToHex,
+		"to_base36":      ToBase36,
+		"toBase36":       ToBase36,
+		"human_bytes":    HumanBytes,
+		"humanBytes":     HumanBytes,
+		"human_bytes_si": HumanBytesSI,
+		"humanBytesSI":   HumanBytesSI,
+		"duration":       Duration,
+		"human_duration": HumanDuration,
+		"humanDuration":  HumanDuration,
+		"percent":        Percent,
+		"round_to":       RoundTo,
+		"roundTo":        RoundTo,
+		"ceil":           Ceil,
+		"floor":          Floor,
+		"roman":          Roman,
+		"next":           Sequencer(),
+		"keynext":        keySequencer,
+		"keyNext":        keySequencer,
+		"inc":            Step,
+		"add":            Add,
+		"sub":            Sub,
+		"mul":            Mul,
+		"div":            SafeDiv,
+		"div_":           Div,
+		"mod":            Mod,
+		"rand":           Rand,
+		"uuid_v4":        UUIDv4,
+		"uuidV4":         UUIDv4,
+		"uuid_v5":        UUIDv5,
+		"uuidV5":         UUIDv5,
+		"ulid":           ULID,
+		"ksuid":          KSUID,
+		"short_id":       ShortID,
+		"shortID":        ShortID,
+		"slug_id":        SlugID,
+		"slugID":         SlugID,
+		"semver_parse":   SemverParse,
+		"semverParse":    SemverParse,
+		"semver_bump":    SemverBump,
+		"semverBump":     SemverBump,
+		"semver_pre":     SemverPrerelease,
+		"semverPre":      SemverPrerelease,
+		"semver_cmp":     SemverCompare,
+		"semverCmp":      SemverCompare,
+		"semver_sort":    SemverSort,
+		"semverSort":     SemverSort,
+		"semver_check":   SemverCheck,
+		"semverCheck":    SemverCheck,
+		"semver_max":     SemverMax,
+		"semverMax":      SemverMax,
+		"identifier":     Cleanse(`^[^[:alpha:]_]+|[^[:alnum:]_]`),
+		"cleanse":        Cleanse(`[^[:alpha:]]`),
+		"cleanser":       Cleanser,
+		"environment":    Environment,
+		"env":            Environment,
+		"env_default":    EnvDefault,
+		"envDefault":     EnvDefault,
+		"env_required":   EnvRequired,
+		"envRequired":    EnvRequired,
+		"env_bool":       EnvBool,
+		"envBool":        EnvBool,
+		"env_int":        EnvInt,
+		"envInt":         EnvInt,
+		"env_prefix":     EnvPrefix,
+		"envPrefix":      EnvPrefix,
+		"now":            time.Now,
+		"started":        Starter,
+		"iindex":         Index,
+		"get":            Get,
+		"get_strict":     GetStrict,
+		"getStrict":      GetStrict,
+		"first":          First,
+		"last":           Last,
+		"rest":           Rest,
+		"sublist":        Sublist,
+		"reverse":        Reverse,
+		"sort":           Sort,
+		"sort_by":        SortBy,
+		"sortBy":         SortBy,
+		"uniq":           Uniq,
+		"includes":       Includes,
+		"where":          Where,
+		"group_by":       GroupBy,
+		"groupBy":        GroupBy,
+		"pluck_path":     PluckPath,
+		"pluckPath":      PluckPath,
+		"sorted_keys":    SortedKeys,
+		"sortedKeys":     SortedKeys,
+		"sorted_values":  SortedValues,
+		"sortedValues":   SortedValues,
+		"merge_maps":     MergeMaps,
+		"mergeMaps":      MergeMaps,
+		"chunk":          Chunk,
+		"zip":            Zip,
+		"split":          Split,
+		"join":           Join,
+		"substr":         Substr,
+		"lower":          strings.ToLower,
+		"toLower":        strings.ToLower,
+		"replace":        strings.Replace,
+		"replace_":       ReReplace,
+		"title":          strings.Title,
+		"initcap":        ReInitcap,
+		"trim":           strings.Trim,
+		"trim_":          ReTrim,
+		"trim_left":      strings.TrimLeft,
+		"trimLeft":       strings.TrimLeft,
+		"trim_left_":     ReTrimLeft,
+		"trimLeft_":      ReTrimLeft,
+		"trim_right":     strings.TrimRight,
+		"trimRight":      strings.TrimRight,
+		"trim_right_":    ReTrimRight,
+		"trimRight_":     ReTrimRight,
+		"upper":          strings.ToUpper,
+		"toUpper":        strings.ToUpper,
+		"basename":       Basename,
+		"basename_":      ReBasename,
+		"dirname":        filepath.Dir,
+		"ext":            filepath.Ext,
+		"path_join":      PathJoin,
+		"pathJoin":       PathJoin,
+		"rel":            Rel,
+		"abs":            filepath.Abs,
+		"clean":          filepath.Clean,
+		"glob":           filepath.Glob,
+		"match":          Match,
+		"split_list":     filepath.SplitList,
+		"to_slash":       ToSlash,
+		"toSlash":        ToSlash,
+		"to_backslash":   ToBackslash,
+		"toBackslash":    ToBackslash,
+		"to_json":        ToJSON,
+		"toJSON":         ToJSON,
+		"to_pretty_json": ToPrettyJSON,
+		"toPrettyJSON":   ToPrettyJSON,
+		"from_json":      FromJSON,
+		"fromJSON":       FromJSON,
+		"to_yaml":        ToYAML,
+		"toYAML":         ToYAML,
+		"from_yaml":      FromYAML,
+		"fromYAML":       FromYAML,
+		"to_toml":        ToTOML,
+		"toTOML":         ToTOML,
+		"from_toml":      FromTOML,
+		"fromTOML":       FromTOML,
 	}
 
 	for k, f := range v1Map {
 		Map[k] = f
 	}
+
+	v2Map = template.FuncMap{}
+	for k, f := range v1Map {
+		if _, ok := sprigNames[k]; !ok {
+			v2Map[k] = f
+		}
+	}
+
+	v4Map = template.FuncMap{}
+	for k, f := range v1Map {
+		v4Map[k] = f
+	}
+	for k, f := range (template.FuncMap{
+		"replace":    ReReplace,
+		"trim":       ReTrim,
+		"trim_left":  ReTrimLeft,
+		"trimLeft":   ReTrimLeft,
+		"trim_right": ReTrimRight,
+		"trimRight":  ReTrimRight,
+		"basename":   ReBasename,
+	}) {
+		v4Map[k] = f
+	}
+
+	safeMap = template.FuncMap{}
+	for k, f := range v4Map {
+		if _, unsafe := unsafeNames[k]; !unsafe {
+			safeMap[k] = f
+		}
+	}
 }
 
 var Map = template.FuncMap{}
@@ -208,6 +593,29 @@ var Map = template.FuncMap{}
 //
 var v1Map template.FuncMap
 
+// v1Map without sprigNames.
+var v2Map template.FuncMap
+
+// The functions added since the v2 map that would replace sprig's functions of the same name.
+var sprigNames = map[string]struct{}{
+	"first": {}, "last": {}, "rest": {}, "reverse": {}, "uniq": {}, "ceil": {}, "floor": {}, "clean": {},
+}
+
+// v1Map with the subject of every function last.
+var v4Map template.FuncMap
+
+// v4Map without unsafeNames.
+var safeMap template.FuncMap
+
+// The functions that reach outside of the template, or affect every template.
+var unsafeNames = map[string]struct{}{
+	"abs": {}, "glob": {}, "pause": {}, "command_line": {}, "commandLine": {},
+	"environment": {}, "env": {}, "env_default": {}, "envDefault": {}, "env_required": {},
+	"envRequired": {}, "env_bool": {}, "envBool": {}, "env_int": {}, "envInt": {},
+	"env_prefix": {}, "envPrefix": {}, "debugging": {}, "debug_toggle": {}, "debugToggle": {},
+	"next": {}, "keynext": {}, "keyNext": {},
+}
+
 // To report a consistent time through a single template.
 func Starter() func() time.Time {
 	started := clock.Now("")
@@ -216,11 +624,15 @@ func Starter() func() time.Time {
 
 //
 func Debug(any ...interface{}) string {
-	s := make([]string, len(any))
+	b := strings.Builder{}
+	b.Grow(32 * len(any))
 	for i, a := range any {
-		s[i] = fmt.Sprintf("%[1]T %[1]v", a)
+		if i != 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%[1]T %[1]v", a)
 	}
-	return Join(" ", s)
+	return b.String()
 }
 
 // toggle debugging
@@ -256,6 +668,7 @@ func ReInitcap(s string) string                  { return strings.Title(strings.
 func ReTrim(cut, s string) string                { return strings.Trim(s, cut) }
 func ReTrimLeft(cut, s string) string            { return strings.TrimLeft(s, cut) }
 func ReTrimRight(cut, s string) string           { return strings.TrimRight(s, cut) }
+func ReBasename(ext, path string) string         { return Basename(path, ext) }
 func Rand() int64                                { return rand.Int63() }
 
 // simple sequence generation.
@@ -317,9 +730,10 @@ func SafeDiv(a, b int64) int64 {
 	return b / a
 }
 
-//
+// Remove the matches of the pattern `r` from `s`. Patterns are compiled once and cached, so that
+// a call allocates little more than the result.
 func Cleanser(r, s string) string {
-	return regexp.MustCompile(r).ReplaceAllString(s, "")
+	return compiled(r).ReplaceAllString(s, "")
 }
 
 //
@@ -353,28 +767,40 @@ func CIDRNext(cidr uint8, lowest, count, inc int8, addr []int64) []int64 {
 	return addr
 }
 
-//
+// Increment `value` within the `count` values from `lowest`, cyclically. `value` is unchanged if
+// there are no such values.
 func IPCalc(bits int32, lowest, count, inc, value int64) int64 {
+	if bits <= 0 || count <= 0 {
+		return value
+	}
 	if value < lowest {
 		value += int64(bits)
 	}
-	return (lowest + (value-lowest+inc)%count) % int64(bits)
+	return (lowest + modulo(value-lowest+inc, count)) % int64(bits)
 }
 
 // Given a zero-based, left-to-right IP group index, lowest value, count, and increment,
-// increment the group, cyclically.
+// increment the group, cyclically. A count of zero is every value from the lowest to the top of
+// the group, as it is for IP4Next, IP4Prev, IP6Next and IP6Prev.
 func IPAdd(bits int32, group uint8, lowest, count uint16, inc int16, addr []int64) []int64 {
-	if group >= uint8(len(addr)) {
+	if bits <= 0 || int(group) >= len(addr) {
 		return addr
 	}
 	if lowest == 0 && count == 0 {
-		addr[group] = (addr[group] + int64(inc)) % int64(bits)
+		addr[group] = modulo(addr[group]+int64(inc), int64(bits))
+	} else if count == 0 {
+		addr[group] = IPCalc(bits, int64(lowest), int64(bits)-int64(lowest), int64(inc), addr[group])
 	} else {
-		addr[group] = IPCalc(int32(bits), int64(lowest), int64(count), int64(inc), addr[group])
+		addr[group] = IPCalc(bits, int64(lowest), int64(count), int64(inc), addr[group])
 	}
 	return addr
 }
 
+// The remainder of a / n, from 0 to n-1, even for a negative a.
+func modulo(a, n int64) int64 {
+	return (a%n + n) % n
+}
+
 //
 func IP4Inc(group uint8, inc int8, addr string) string {
 	return IP4Join(IP4Add(group, 0, 0, inc, IPInts(addr)))
@@ -451,29 +877,20 @@ func Split(sep, s string) []string {
 	return strings.Split(s, sep)
 }
 
-//
+// Element `i` of a list or string, or -1 if there is none, found the way `get` finds `[i]`, so
+// that it takes lists of any type and counts the characters, not the bytes, of a string.
 func Index(i int, a interface{}) interface{} {
 	if a == nil {
 		return nil
 	}
-	switch a := a.(type) {
-	case []string:
-		if i < 0 || i >= len(a) {
-			return -1
-		}
-		return a[i]
-	case []int64:
-		if i < 0 || i >= len(a) {
-			return -1
-		}
-		return a[i]
-	case string:
-		if i < 0 || i >= len(a) {
-			return -1
-		}
-		return fmt.Sprintf("%c", a[i])
+	if i < 0 {
+		return -1
 	}
-	return a
+	v, err := GetStrict("["+strconv.Itoa(i)+"]", a)
+	if err != nil {
+		return -1
+	}
+	return v
 }
 
 //
@@ -500,7 +917,7 @@ func IPInts(addr string) []int64 {
 	if ip_groups := Split(".", addr); len(ip_groups) > 1 {
 		return DecToInt(ip_groups)
 	} else {
-		return HexToInt(strings.Split(":", addr))
+		return HexToInt(Split(":", addr))
 	}
 }
 
@@ -539,65 +956,51 @@ func FromInt(format string, arr []int64) []string {
 
 // Performs IP math using a simple sequence of operations.
 // e.g. _.[+2]._.[+1,%10]
+// The operations are parsed once and cached, so that only the result is allocated.
 func IPMath(math, addr string) string {
-	sep, format, width := ".", "%d", uint(256)
-	ip_groups := Split(sep, addr)
-	th_groups := Split(sep, math)
-	parser := parseDec
-	if len(ip_groups) == 1 {
-		parser = parseHex
-		sep, format, width = ":", "%04x", uint(65536)
-		ip_groups = Split(sep, addr)
-		th_groups = Split(sep, math)
-	}
-	if len(ip_groups) != len(th_groups) {
+	return ipMath(rand.Int63n, math, addr)
+}
+
+// IPMath with `[R]` operands from `random`.
+func ipMath(random func(int64) int64, math, addr string) string {
+	sep, base, width := byte('.'), 10, int64(256)
+	if strings.IndexByte(addr, '.') < 0 {
+		sep, base, width = ':', 16, 65536
+	}
+	groups := parseIPMath(math, sep)
+	if strings.Count(addr, string(sep))+1 != len(groups) {
 		return addr
 	}
-	ip_values := make([]int64, len(ip_groups))
-	for i, m := range ip_groups {
-		p, err := parser(m)
-		if err != nil {
-			continue
+
+	b := strings.Builder{}
+	b.Grow(len(addr) + len(groups))
+	digits := [20]byte{}
+	rest := addr
+	for i, ops := range groups {
+		group := rest
+		if j := strings.IndexByte(rest, sep); j >= 0 {
+			group, rest = rest[:j], rest[j+1:]
 		}
-		ip_values[i] = p
-	}
-	for i, m := range th_groups {
-		m := m
-		lm := len(m)
-		if lm < 3 {
-			continue
+		if i != 0 {
+			b.WriteByte(sep)
 		}
-		switch m {
-		case "_":
+		if ops == nil {
+			b.WriteString(group)
 			continue
 		}
-		if m[0] != '[' || m[lm-1] != ']' {
-			continue
+		p, err := strconv.ParseInt(group, base, 64)
+		if err != nil {
+			p = 0
 		}
-		m = m[1 : lm-1]
-		p := ip_values[i]
-		for _, a := range strings.Split(m, ",") {
-			a := a
-			op := a[0]
-			switch op {
-			case '+', '-', '*', '/', '%':
-				a = a[1:]
-			default:
+		for _, o := range ops {
+			n := o.n
+			if o.random {
+				n = random(width)
 			}
-
-			n := int64(0)
-			switch a {
-			case "R":
-				n = rand.Int63n(int64(width))
-			default:
-				x, err := parser(a)
-				if err != nil {
-					continue
-				}
-				n = x
+			if n == 0 && (o.op == '/' || o.op == '%') {
+				continue
 			}
-
-			switch op {
+			switch o.op {
 			case '+':
 				p += n
 			case '-':
@@ -611,11 +1014,64 @@ func IPMath(math, addr string) string {
 			default:
 				p = n
 			}
-			p %= int64(width)
+			p %= width
 		}
-		ip_groups[i] = fmt.Sprintf(format, uint(p)%width)
+		v := strconv.AppendUint(digits[:0], uint64(p)%uint64(width), base)
+		for pad := 4 - len(v); base == 16 && pad > 0; pad-- {
+			b.WriteByte('0')
+		}
+		b.Write(v)
+	}
+	return b.String()
+}
+
+// An operation of an IPMath group: an operator and its operand, unless it is random.
+type ipOp struct {
+	op     byte
+	n      int64
+	random bool
+}
+
+var ip4Maths, ip6Maths = newCache(256), newCache(256)
+
+// The operations of each group of `math`, nil for groups that are left alone, with decimal
+// operands for IPv4, separated by `.`, or hex for IPv6.
+func parseIPMath(math string, sep byte) [][]ipOp {
+	maths, parse := ip4Maths, parseDec
+	if sep == ':' {
+		maths, parse = ip6Maths, parseHex
 	}
-	return Join(sep, ip_groups)
+	return maths.get(math, func() interface{} {
+		groups := [][]ipOp{}
+		for _, m := range strings.Split(math, string(sep)) {
+			lm := len(m)
+			if lm < 3 || m[0] != '[' || m[lm-1] != ']' {
+				groups = append(groups, nil)
+				continue
+			}
+			ops := []ipOp{}
+			for _, a := range strings.Split(m[1:lm-1], ",") {
+				if a == "" {
+					continue
+				}
+				o := ipOp{op: a[0]}
+				switch o.op {
+				case '+', '-', '*', '/', '%':
+					a = a[1:]
+				}
+				if a == "R" {
+					o.random = true
+				} else if n, err := parse(a); err == nil {
+					o.n = n
+				} else {
+					continue
+				}
+				ops = append(ops, o)
+			}
+			groups = append(groups, ops)
+		}
+		return groups
+	}).([][]ipOp)
 }
 
 // Reproduce a command line string that reflects a usable command line.
@@ -9,6 +9,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/Masterminds/sprig"
 	"github.com/gomatic/clock"
 	"github.com/stretchr/testify/assert"
 )
@@ -630,7 +631,10 @@ func TestIP4Inc(t *testing.T) {
 		args args
 		want string
 	}{
-		// TODO: Add test cases.
+		{name: "inc", args: args{3, 5, "10.0.0.1"}, want: "10.0.0.6"},
+		{name: "wraps", args: args{3, 1, "10.0.0.255"}, want: "10.0.0.0"},
+		{name: "wraps down", args: args{0, -1, "0.0.0.1"}, want: "255.0.0.1"},
+		{name: "no group", args: args{4, 1, "10.0.0.1"}, want: "10.0.0.1"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -675,7 +679,9 @@ func TestIP4Next(t *testing.T) {
 		args args
 		want string
 	}{
-		// TODO: Add test cases.
+		{name: "next", args: args{3, 1, 10, "10.0.0.5"}, want: "10.0.0.6"},
+		{name: "cycles", args: args{3, 1, 10, "10.0.0.10"}, want: "10.0.0.1"},
+		{name: "to the top", args: args{3, 200, 0, "10.0.0.255"}, want: "10.0.0.200"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -699,7 +705,9 @@ func TestIP4Prev(t *testing.T) {
 		args args
 		want string
 	}{
-		// TODO: Add test cases.
+		{name: "prev", args: args{3, 1, 10, "10.0.0.5"}, want: "10.0.0.4"},
+		{name: "cycles", args: args{3, 1, 254, "10.0.0.1"}, want: "10.0.0.254"},
+		{name: "to the top", args: args{3, 200, 0, "10.0.0.200"}, want: "10.0.0.255"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -747,7 +755,8 @@ func TestIP6Inc(t *testing.T) {
 		args args
 		want string
 	}{
-		// TODO: Add test cases.
+		{name: "inc", args: args{3, 1, "fe80::0:1"}, want: "fe80:0000:0000:0002"},
+		{name: "wraps down", args: args{0, -1, "0:0:0:0:0:0:0:1"}, want: "ffff:0000:0000:0000:0000:0000:0000:0001"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -792,7 +801,7 @@ func TestIP6Next(t *testing.T) {
 		args args
 		want string
 	}{
-		// TODO: Add test cases.
+		{name: "cycles", args: args{1, 0, 16, "fe80:f"}, want: "fe80:0000"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -816,7 +825,7 @@ func TestIP6Prev(t *testing.T) {
 		args args
 		want string
 	}{
-		// TODO: Add test cases.
+		{name: "cycles", args: args{1, 0, 16, "fe80:0"}, want: "fe80:000f"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -842,7 +851,11 @@ func TestIPAdd(t *testing.T) {
 		args args
 		want []int64
 	}{
-		// TODO: Add test cases.
+		{name: "no bits", args: args{0, 0, 0, 0, 1, []int64{1}}, want: []int64{1}},
+		{name: "no group", args: args{256, 1, 0, 0, 1, []int64{1}}, want: []int64{1}},
+		{name: "no count", args: args{256, 0, 200, 0, 1, []int64{254}}, want: []int64{255}},
+		{name: "no count wraps to lowest", args: args{256, 0, 200, 0, 1, []int64{255}}, want: []int64{200}},
+		{name: "no lowest or count", args: args{256, 0, 0, 0, -1, []int64{0}}, want: []int64{255}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -867,7 +880,10 @@ func TestIPCalc(t *testing.T) {
 		args args
 		want int64
 	}{
-		// TODO: Add test cases.
+		{name: "next", args: args{256, 1, 10, 1, 5}, want: 6},
+		{name: "prev of lowest", args: args{256, 1, 10, -1, 1}, want: 10},
+		{name: "no values", args: args{256, 1, 0, 1, 5}, want: 5},
+		{name: "negative count", args: args{256, 1, -1, 1, 5}, want: 5},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -888,7 +904,8 @@ func TestIPInts(t *testing.T) {
 		args args
 		want []int64
 	}{
-		// TODO: Add test cases.
+		{name: "v4", args: args{"10.0.0.1"}, want: []int64{10, 0, 0, 1}},
+		{name: "v6", args: args{"fe80::ff"}, want: []int64{0xfe80, 0, 0xff}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -953,7 +970,15 @@ func TestIndex(t *testing.T) {
 		args args
 		want interface{}
 	}{
-		// TODO: Add test cases.
+		{name: "nil", args: args{0, nil}, want: nil},
+		{name: "strings", args: args{1, []string{"a", "b"}}, want: "b"},
+		{name: "int64s", args: args{0, []int64{7}}, want: int64(7)},
+		{name: "mixed", args: args{1, []interface{}{"a", 2}}, want: 2},
+		{name: "ints", args: args{0, []int{3}}, want: 3},
+		{name: "string", args: args{1, "héllo"}, want: "é"},
+		{name: "out of range", args: args{2, []string{"a", "b"}}, want: -1},
+		{name: "negative", args: args{-1, []string{"a", "b"}}, want: -1},
+		{name: "not a list", args: args{0, 5}, want: -1},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1145,7 +1170,8 @@ func TestNew(t *testing.T) {
 		args args
 		want template.FuncMap
 	}{
-		// TODO: Add test cases.
+		{name: "empty", want: template.FuncMap{}},
+		{name: "nil", args: args{[]Optional{nil, WithMap(nil), WithMap(template.FuncMap{"x": nil})}}, want: template.FuncMap{}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1156,6 +1182,16 @@ func TestNew(t *testing.T) {
 	}
 }
 
+//
+func TestWithV2Map(t *testing.T) {
+	v1, v2, sprigMap := New(WithV1Map()), New(WithV2Map()), sprig.GenericFuncMap()
+	for _, name := range []string{"first", "last", "rest", "reverse", "uniq", "ceil", "floor", "clean"} {
+		assert.True(t, sameFunc(v2[name], sprigMap[name]), name)
+		assert.False(t, sameFunc(v1[name], sprigMap[name]), name)
+	}
+	assert.True(t, sameFunc(v2["substr"], Substr))
+}
+
 //
 func TestParseInt(t *testing.T) {
 	tests := []struct {
@@ -1552,7 +1588,21 @@ func TestToInt(t *testing.T) {
 		args args
 		want []int64
 	}{
-		// TODO: Add test cases.
+		{
+			name: "decimal",
+			args: args{base: 10, arr: []string{"1", "-2", "30"}},
+			want: []int64{1, -2, 30},
+		},
+		{
+			name: "hex",
+			args: args{base: 16, arr: []string{"ff", "10"}},
+			want: []int64{255, 16},
+		},
+		{
+			name: "zero filled",
+			args: args{base: 10, arr: []string{"1", "x", "0x10"}},
+			want: []int64{1, 0, 0},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1571,19 +1621,45 @@ func TestWithClock(t *testing.T) {
 	tests := []struct {
 		name string
 		args args
-		want Optional
+		want time.Time
 	}{
-		// TODO: Add test cases.
+		{name: "nil", args: args{nil}},
+		{name: "playground", args: args{clock.Playground.MustTime()}, want: clock.Playground.MustTime()()},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := WithClock(tt.args.timeFunc); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("WithClock() = %v, want %v", got, tt.want)
+			got := time.Time{}
+			if now, ok := New(WithClock(tt.args.timeFunc))["now"].(clock.TimeFunction); ok {
+				got = now()
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("WithClock() now = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+//
+func TestWithClockStarted(t *testing.T) {
+	fm := New(WithV1Map(), WithClock(clock.Playground.MustTime()))
+	started := fm["started"].(func() func() time.Time)()
+	assert.Equal(t, clock.Playground.MustTime()(), started())
+}
+
+//
+func TestWithSequencers(t *testing.T) {
+	next := func(fm template.FuncMap) (int64, int64) {
+		return fm["next"].(func() int64)(), fm["keynext"].(func(string) int64)("k")
+	}
+	fm := New(WithV1Map(), WithSequencers())
+	n, k := next(fm)
+	assert.Equal(t, []int64{1, 1}, []int64{n, k})
+	n, k = next(fm)
+	assert.Equal(t, []int64{2, 2}, []int64{n, k})
+	n, k = next(New(WithV1Map(), WithSequencers()))
+	assert.Equal(t, []int64{1, 1}, []int64{n, k})
+}
+
 //
 func TestWithMaps(t *testing.T) {
 	tests := []struct {
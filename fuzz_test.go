@@ -0,0 +1,101 @@
+package funcmap
+
+import (
+	"strings"
+	"testing"
+)
+
+//
+func FuzzSubstr(f *testing.F) {
+	f.Add(0, -1, "0123456789abcdef")
+	f.Add(13, -17, "0123456789abcdef")
+	f.Add(-9223372036854775808, 9223372036854775807, "é")
+	f.Fuzz(func(t *testing.T, start, end int, s string) {
+		got := Substr(start, end, s)
+		if !strings.Contains(s, got) {
+			t.Errorf("Substr(%d, %d, %q) = %q is not a part of it", start, end, s, got)
+		}
+	})
+}
+
+//
+func FuzzIPMath(f *testing.F) {
+	f.Add("_._._.[+1]", "10.0.0.1")
+	f.Add("[R].[*2,%3].[/0].[%0,]", "10.0.0.1")
+	f.Add("_:_:_:_:_:_:_:[+1]", "fe80:0:0:0:0:0:0:1")
+	f.Add("[,].[-].[+x].[", "1.2.3.4")
+	f.Fuzz(func(t *testing.T, math, addr string) {
+		got := IPMath(math, addr)
+		if ints := IPInts(addr); len(ints) != 4 || strings.Count(math, ".") != 3 || IP4Join(ints) != addr || !validIP4(ints) {
+			return
+		}
+		if ints := IPInts(got); len(ints) != 4 || !validIP4(ints) {
+			t.Errorf("IPMath(%q, %q) = %q is not an IPv4 address", math, addr, got)
+		}
+	})
+}
+
+func validIP4(ints []int64) bool {
+	for _, i := range ints {
+		if i < 0 || i > 255 {
+			return false
+		}
+	}
+	return true
+}
+
+//
+func FuzzIP4NextPrev(f *testing.F) {
+	f.Add(uint32(0x0a000001), uint8(3), uint8(0), uint8(0), int8(1))
+	f.Add(uint32(0x0a0000ff), uint8(3), uint8(1), uint8(254), int8(-1))
+	f.Add(uint32(0x0a000000), uint8(3), uint8(0), uint8(0), int8(-128))
+	f.Fuzz(func(t *testing.T, ip uint32, group, lowest, count uint8, inc int8) {
+		addr := IP4Join([]int64{int64(ip >> 24), int64(ip >> 16 & 0xff), int64(ip >> 8 & 0xff), int64(ip & 0xff)})
+		if inc != -128 {
+			if got := IP4Inc(group, -inc, IP4Inc(group, inc, addr)); got != addr {
+				t.Errorf("IP4Inc(%d, %d) of IP4Inc(%d, %d, %q) = %q", group, -inc, group, inc, addr, got)
+			}
+		}
+		if v := IPInts(addr)[group%4]; group > 3 || v < int64(lowest) || count != 0 && (v >= int64(lowest)+int64(count) || int(lowest)+int(count) > 256) {
+			return
+		}
+		if got := IP4Prev(group, lowest, count, IP4Next(group, lowest, count, addr)); got != addr {
+			t.Errorf("IP4Prev(%d, %d, %d) of IP4Next(%[1]d, %d, %d, %q) = %q", group, lowest, count, addr, got)
+		}
+	})
+}
+
+//
+func FuzzIP6NextPrev(f *testing.F) {
+	f.Add([]byte("\xfe\x80\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xff\xff"), uint8(7), uint16(0), uint16(0))
+	f.Add([]byte{}, uint8(0), uint16(1), uint16(0))
+	f.Fuzz(func(t *testing.T, b []byte, group uint8, lowest, count uint16) {
+		b = append(b, make([]byte, 16)...)
+		ints := make([]int64, 8)
+		for i := range ints {
+			ints[i] = int64(b[2*i])<<8 | int64(b[2*i+1])
+		}
+		addr := IP6Join(ints)
+		if got := IP6Join(IPInts(addr)); got != addr {
+			t.Fatalf("IP6Join(IPInts(%q)) = %q", addr, got)
+		}
+		if v := ints[group%8]; group > 7 || v < int64(lowest) || count != 0 && (v >= int64(lowest)+int64(count) || int(lowest)+int(count) > 65536) {
+			return
+		}
+		if got := IP6Prev(group, lowest, count, IP6Next(group, lowest, count, addr)); got != addr {
+			t.Errorf("IP6Prev(%d, %d, %d) of IP6Next(%[1]d, %d, %d, %q) = %q", group, lowest, count, addr, got)
+		}
+	})
+}
+
+//
+func FuzzIPInts(f *testing.F) {
+	f.Add("10.0.0.1")
+	f.Add("::1")
+	f.Add("1.2.3.4.5.6")
+	f.Fuzz(func(t *testing.T, addr string) {
+		if ints, groups := IPInts(addr), IPSplit(addr); len(ints) != len(groups) {
+			t.Errorf("IPInts(%q) = %v but IPSplit = %q", addr, ints, groups)
+		}
+	})
+}
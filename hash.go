@@ -0,0 +1,123 @@
+package funcmap
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"strings"
+	"text/template"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// Digest and encoding functions without the rest of sprig.
+func WithCryptoMap() Optional {
+	return WithMaps(cryptoMap)
+}
+
+//
+var cryptoMap = template.FuncMap{
+	"md5":              MD5,
+	"sha1":             SHA1,
+	"sha1sum":          SHA1,
+	"sha256":           SHA256,
+	"sha256sum":        SHA256,
+	"sha512":           SHA512,
+	"crc32":            CRC32,
+	"fnv":              FNV,
+	"xxhash":           XXHash,
+	"short_hash":       ShortHash,
+	"shortHash":        ShortHash,
+	"hex":              HexEncode,
+	"hex_decode":       HexDecode,
+	"hexDecode":        HexDecode,
+	"base32":           Base32Encode,
+	"base32_decode":    Base32Decode,
+	"base32Decode":     Base32Decode,
+	"base64":           Base64Encode,
+	"base64_decode":    Base64Decode,
+	"base64Decode":     Base64Decode,
+	"base64url":        Base64URLEncode,
+	"base64url_decode": Base64URLDecode,
+	"base64urlDecode":  Base64URLDecode,
+}
+
+//
+func MD5(s string) string { return fmt.Sprintf("%x", md5.Sum([]byte(s))) }
+
+//
+func SHA1(s string) string { return fmt.Sprintf("%x", sha1.Sum([]byte(s))) }
+
+//
+func SHA256(s string) string { return fmt.Sprintf("%x", sha256.Sum256([]byte(s))) }
+
+//
+func SHA512(s string) string { return fmt.Sprintf("%x", sha512.Sum512([]byte(s))) }
+
+// IEEE CRC-32 as 8 hex digits.
+func CRC32(s string) string { return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(s))) }
+
+// 64-bit FNV-1a as 16 hex digits.
+func FNV(s string) string {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// 64-bit xxHash as 16 hex digits.
+func XXHash(s string) string { return fmt.Sprintf("%016x", xxhash.Sum64String(s)) }
+
+// The first `length` hex digits, from 1 to 64, of the SHA-256 of `s`.
+func ShortHash(length int, s string) string {
+	h := SHA256(s)
+	if length < 1 {
+		length = 1
+	}
+	if length > len(h) {
+		length = len(h)
+	}
+	return h[:length]
+}
+
+//
+func HexEncode(s string) string { return hex.EncodeToString([]byte(s)) }
+
+//
+func HexDecode(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	return string(b), err
+}
+
+//
+func Base32Encode(s string) string { return base32.StdEncoding.EncodeToString([]byte(s)) }
+
+//
+func Base32Decode(s string) (string, error) {
+	b, err := base32.StdEncoding.DecodeString(s)
+	return string(b), err
+}
+
+//
+func Base64Encode(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+//
+func Base64Decode(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	return string(b), err
+}
+
+// URL-safe base64 without padding.
+func Base64URLEncode(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+// URL-safe base64, with or without padding.
+func Base64URLDecode(s string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	return string(b), err
+}
@@ -0,0 +1,80 @@
+package funcmap
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestDigests(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(string) string
+		want string
+	}{
+		{name: "md5", f: MD5, want: "900150983cd24fb0d6963f7d28e17f72"},
+		{name: "sha1", f: SHA1, want: "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{name: "sha256", f: SHA256, want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{name: "sha512", f: SHA512, want: "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+		{name: "crc32", f: CRC32, want: "352441c2"},
+		{name: "fnv", f: FNV, want: "e71fa2190541574b"},
+		{name: "xxhash", f: XXHash, want: "44bc2cf5ad770999"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f("abc"); got != tt.want {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestShortHash(t *testing.T) {
+	assert.Equal(t, "ba7816bf", ShortHash(8, "abc"))
+	assert.Equal(t, "b", ShortHash(0, "abc"))
+	assert.Len(t, ShortHash(100, "abc"), 64)
+}
+
+//
+func TestEncodings(t *testing.T) {
+	tests := []struct {
+		name   string
+		encode func(string) string
+		decode func(string) (string, error)
+		want   string
+	}{
+		{name: "hex", encode: HexEncode, decode: HexDecode, want: "3f3e3f"},
+		{name: "base32", encode: Base32Encode, decode: Base32Decode, want: "H47D6==="},
+		{name: "base64", encode: Base64Encode, decode: Base64Decode, want: "Pz4/"},
+		{name: "base64url", encode: Base64URLEncode, decode: Base64URLDecode, want: "Pz4_"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.encode("?>?")
+			assert.Equal(t, tt.want, got)
+			back, err := tt.decode(got)
+			assert.NoError(t, err)
+			assert.Equal(t, "?>?", back)
+			_, err = tt.decode("!")
+			assert.Error(t, err)
+		})
+	}
+	back, err := Base64URLDecode("YQ==")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", back)
+}
+
+//
+func TestWithCryptoMap(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(New(WithCryptoMap())).Parse(`{{ . | sha256sum | printf "%.8s" }} {{ short_hash 6 . }} {{ base64 . }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, "abc"))
+	assert.Equal(t, "ba7816bf ba7816 YWJj", b.String())
+
+	_, exists := New(WithV1Map())["sha256"]
+	assert.False(t, exists)
+}
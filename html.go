@@ -0,0 +1,47 @@
+package funcmap
+
+import (
+	htmltemplate "html/template"
+	"text/template"
+)
+
+// Like New, for html/template, which escapes what the functions return for where it is used in
+// the page. To mark trusted content as safe from that escaping, opt in with WithSafeTypes.
+func NewHTML(options ...Optional) htmltemplate.FuncMap {
+	return htmltemplate.FuncMap(New(options...))
+}
+
+// Functions that mark a string as safe to use, unescaped, as HTML, an HTML attribute, a URL,
+// JavaScript or CSS in html/template. They must only be given trusted content.
+func WithSafeTypes() Optional {
+	return WithMaps(htmlMap)
+}
+
+//
+var htmlMap = template.FuncMap{
+	"safe_html": SafeHTML,
+	"safeHTML":  SafeHTML,
+	"safe_attr": SafeHTMLAttr,
+	"safeAttr":  SafeHTMLAttr,
+	"safe_url":  SafeURL,
+	"safeURL":   SafeURL,
+	"safe_js":   SafeJS,
+	"safeJS":    SafeJS,
+	"safe_css":  SafeCSS,
+	"safeCSS":   SafeCSS,
+}
+
+//
+func SafeHTML(s string) htmltemplate.HTML { return htmltemplate.HTML(s) }
+
+// An attribute and its value, such as `dir="ltr"`.
+func SafeHTMLAttr(s string) htmltemplate.HTMLAttr { return htmltemplate.HTMLAttr(s) }
+
+//
+func SafeURL(s string) htmltemplate.URL { return htmltemplate.URL(s) }
+
+//
+func SafeJS(s string) htmltemplate.JS { return htmltemplate.JS(s) }
+
+//
+func SafeCSS(s string) htmltemplate.CSS { return htmltemplate.CSS(s) }
@@ -0,0 +1,35 @@
+package funcmap
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestNewHTML(t *testing.T) {
+	render := func(funcs htmltemplate.FuncMap, text string) string {
+		tmpl, err := htmltemplate.New("").Funcs(funcs).Parse(text)
+		if !assert.NoError(t, err, text) {
+			return ""
+		}
+		b := bytes.Buffer{}
+		assert.NoError(t, tmpl.Execute(&b, map[string]string{"u": "javascript:alert(1)", "h": "<i>a</i>"}))
+		return b.String()
+	}
+
+	funcs := NewHTML(WithV4Map())
+	assert.Equal(t, `&lt;B&gt;`, render(funcs, `{{ upper "<b>" }}`))
+	assert.Equal(t, `<a href="#ZgotmplZ">`, render(funcs, `<a href="{{ .u }}">`))
+	assert.Equal(t, `<script>x = ["a","\u003cb\u003e"]</script>`, render(funcs, `<script>x = {{ split "," "a,<b>" }}</script>`))
+	assert.NotContains(t, funcs, "safe_html")
+
+	funcs = NewHTML(WithV4Map(), WithSafeTypes())
+	assert.Equal(t, `<i>a</i>`, render(funcs, `{{ .h | safe_html }}`))
+	assert.Equal(t, `<a href="javascript:alert%281%29">`, render(funcs, `<a href="{{ .u | safeURL }}">`))
+	assert.Equal(t, `<p dir="ltr">`, render(funcs, `<p {{ safe_attr "dir=\"ltr\"" }}>`))
+	assert.Equal(t, `<script>x = a && b</script>`, render(funcs, `<script>x = {{ safe_js "a && b" }}</script>`))
+	assert.Equal(t, `<p style="color: red">`, render(funcs, `<p style="{{ safe_css "color: red" }}">`))
+}
@@ -0,0 +1,185 @@
+package funcmap
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ID functions that read randomness from `random` and time from `now`, so that the same source
+// and clock reproduce the same IDs.
+func Identifiers(random io.Reader, now func() time.Time) template.FuncMap {
+	return ids{random: random, now: now}.funcMap()
+}
+
+// A concurrency-safe random source, for Identifiers, seeded with `seed`.
+func SeededReader(seed int64) io.Reader {
+	return &lockedRand{r: rand.New(rand.NewSource(seed))}
+}
+
+//
+func UUIDv4() (string, error) { return defaultIDs.uuidV4() }
+
+// A name-based, SHA-1 UUID. `namespace` is a UUID or one of `dns`, `url`, `oid` or `x500`.
+func UUIDv5(namespace, name string) (string, error) {
+	ns, ok := map[string]uuid.UUID{
+		"dns":  uuid.NameSpaceDNS,
+		"url":  uuid.NameSpaceURL,
+		"oid":  uuid.NameSpaceOID,
+		"x500": uuid.NameSpaceX500,
+	}[strings.ToLower(namespace)]
+	if !ok {
+		var err error
+		if ns, err = uuid.Parse(namespace); err != nil {
+			return "", fmt.Errorf("namespace %q: %v", namespace, err)
+		}
+	}
+	return uuid.NewSHA1(ns, []byte(name)).String(), nil
+}
+
+// A 26 character, lexically sortable ULID.
+func ULID() (string, error) { return defaultIDs.ulid() }
+
+// A 27 character, lexically sortable KSUID.
+func KSUID() (string, error) { return defaultIDs.ksuid() }
+
+// `length` random letters and digits.
+func ShortID(length int) (string, error) { return defaultIDs.shortID(length) }
+
+// `length` random lowercase letters and digits, starting with a letter, usable in DNS names.
+func SlugID(length int) (string, error) { return defaultIDs.slugID(length) }
+
+var defaultIDs = ids{random: crand.Reader, now: time.Now}
+
+type ids struct {
+	random io.Reader
+	now    func() time.Time
+}
+
+func (g ids) funcMap() template.FuncMap {
+	return template.FuncMap{
+		"uuid_v4":  g.uuidV4,
+		"uuidV4":   g.uuidV4,
+		"uuid_v5":  UUIDv5,
+		"uuidV5":   UUIDv5,
+		"ulid":     g.ulid,
+		"ksuid":    g.ksuid,
+		"short_id": g.shortID,
+		"shortID":  g.shortID,
+		"slug_id":  g.slugID,
+		"slugID":   g.slugID,
+	}
+}
+
+func (g ids) uuidV4() (string, error) {
+	u, err := uuid.NewRandomFromReader(g.random)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func (g ids) ulid() (string, error) {
+	b := make([]byte, 16)
+	ms := uint64(g.now().UnixNano() / int64(time.Millisecond))
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> uint(40-8*i))
+	}
+	if _, err := io.ReadFull(g.random, b[6:]); err != nil {
+		return "", err
+	}
+	return encode(new(big.Int).SetBytes(b), crockford, 26), nil
+}
+
+const (
+	base62     = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidEpoch = 1400000000
+)
+
+func (g ids) ksuid() (string, error) {
+	b := make([]byte, 20)
+	ts := uint32(g.now().Unix() - ksuidEpoch)
+	b[0], b[1], b[2], b[3] = byte(ts>>24), byte(ts>>16), byte(ts>>8), byte(ts)
+	if _, err := io.ReadFull(g.random, b[4:]); err != nil {
+		return "", err
+	}
+	return encode(new(big.Int).SetBytes(b), base62, 27), nil
+}
+
+func (g ids) shortID(length int) (string, error) {
+	return g.pick(length, base62, base62)
+}
+
+func (g ids) slugID(length int) (string, error) {
+	return g.pick(length, "abcdefghijklmnopqrstuvwxyz", "abcdefghijklmnopqrstuvwxyz0123456789")
+}
+
+// `length` characters, the first from `first` and the rest from `rest`, without modulo bias.
+func (g ids) pick(length int, first, rest string) (string, error) {
+	if length < 1 {
+		return "", fmt.Errorf("length %d is not positive", length)
+	}
+	s := make([]byte, 0, length)
+	b := []byte{0}
+	for len(s) < length {
+		alphabet := rest
+		if len(s) == 0 {
+			alphabet = first
+		}
+		if _, err := io.ReadFull(g.random, b); err != nil {
+			return "", err
+		}
+		if limit := 256 - 256%len(alphabet); int(b[0]) < limit {
+			s = append(s, alphabet[int(b[0])%len(alphabet)])
+		}
+	}
+	return string(s), nil
+}
+
+// `n` in the base of `alphabet`, zero-padded to `width`.
+func encode(n *big.Int, alphabet string, width int) string {
+	s := make([]byte, width)
+	base := big.NewInt(int64(len(alphabet)))
+	m := new(big.Int)
+	for i := width - 1; i >= 0; i-- {
+		n.DivMod(n, base, m)
+		s[i] = alphabet[m.Int64()]
+	}
+	return string(s)
+}
+
+type lockedRand struct {
+	lock sync.Mutex
+	r    *rand.Rand
+}
+
+func (l *lockedRand) Read(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.r.Read(p)
+}
+
+func (l *lockedRand) Int63() int64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.r.Int63()
+}
+
+func (l *lockedRand) Int63n(n int64) int64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.r.Int63n(n)
+}
+
+// IPMath with `[R]` from this source.
+func (l *lockedRand) ipMath(math, addr string) string { return ipMath(l.Int63n, math, addr) }
@@ -0,0 +1,126 @@
+package funcmap
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/gomatic/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestUUIDv5(t *testing.T) {
+	tests := []struct {
+		namespace string
+		name      string
+		want      string
+		wantErr   bool
+	}{
+		{namespace: "dns", name: "python.org", want: "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{namespace: "URL", name: "http://python.org/", want: "4c565f0d-3f5a-5890-b41b-20cf47701c5e"},
+		{namespace: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", name: "python.org", want: "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{namespace: "nope", name: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			got, err := UUIDv5(tt.namespace, tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UUIDv5() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UUIDv5() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestIdentifiers(t *testing.T) {
+	now := func() time.Time { return time.Date(2020, 1, 2, 3, 4, 5, 6000000, time.UTC) }
+	generate := func() []string {
+		g := ids{random: SeededReader(1), now: now}
+		ss := []string{}
+		for _, f := range []func() (string, error){g.uuidV4, g.ulid, g.ksuid} {
+			s, err := f()
+			assert.NoError(t, err)
+			ss = append(ss, s)
+		}
+		for _, f := range []func(int) (string, error){g.shortID, g.slugID} {
+			s, err := f(12)
+			assert.NoError(t, err)
+			ss = append(ss, s)
+		}
+		return ss
+	}
+
+	got := generate()
+	assert.Equal(t, got, generate())
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), got[0])
+	assert.Regexp(t, regexp.MustCompile(`^01DXJ3BK4E[0-9A-HJKMNP-TV-Z]{16}$`), got[1])
+	assert.Regexp(t, regexp.MustCompile(`^1Voz[0-9A-Za-z]{23}$`), got[2])
+	assert.Regexp(t, regexp.MustCompile(`^[0-9A-Za-z]{12}$`), got[3])
+	assert.Regexp(t, regexp.MustCompile(`^[a-z][a-z0-9]{11}$`), got[4])
+
+	_, err := ShortID(0)
+	assert.Error(t, err)
+}
+
+//
+func TestSortableIDs(t *testing.T) {
+	at := time.Unix(1600000000, 0)
+	g := ids{random: SeededReader(1), now: func() time.Time { return at }}
+	prev := map[string]string{}
+	for i := 0; i < 100; i++ {
+		at = at.Add(time.Second)
+		ulid, _ := g.ulid()
+		ksuid, _ := g.ksuid()
+		if i > 0 {
+			assert.True(t, strings.Compare(prev["ulid"], ulid) < 0, "%s < %s", prev["ulid"], ulid)
+			assert.True(t, strings.Compare(prev["ksuid"], ksuid) < 0, "%s < %s", prev["ksuid"], ksuid)
+		}
+		prev["ulid"], prev["ksuid"] = ulid, ksuid
+	}
+}
+
+//
+func TestWithSeed(t *testing.T) {
+	render := func(options ...Optional) string {
+		tmpl := template.Must(template.New("").Funcs(New(options...)).Parse(`{{ uuid_v4 }} {{ ulid }} {{ rand }} {{ ip_math "[R].[R].[R].[R]" "0.0.0.0" }} {{ IPMath "[R]:[R]" "0:0" }}`))
+		b := bytes.Buffer{}
+		assert.NoError(t, tmpl.Execute(&b, nil))
+		return b.String()
+	}
+	options := []Optional{WithV1Map(), WithSeed(42), WithClock(clock.Playground.MustTime())}
+	got := render(options...)
+	assert.Equal(t, got, render(options...))
+	assert.NotEqual(t, got, render(WithV1Map(), WithSeed(43), WithClock(clock.Playground.MustTime())))
+	assert.NotEqual(t, render(WithV1Map()), render(WithV1Map()))
+
+	ip := func(seed int64) string {
+		return New(WithV4Map(), WithSeed(seed))["ip_math"].(func(string, string) string)("[R].[R].[R].[R]", "0.0.0.0")
+	}
+	assert.Equal(t, ip(42), ip(42))
+	assert.NotEqual(t, ip(42), ip(43))
+}
+
+//
+func TestWithSeedOtherMaps(t *testing.T) {
+	custom := template.FuncMap{"ulid": func() string { return "mine" }, "rand": func() int64 { return 4 }}
+	got := New(WithMap(custom), WithSeed(1), WithClock(clock.Playground.MustTime()))
+	assert.Equal(t, "mine", got["ulid"].(func() string)())
+	assert.Equal(t, int64(4), got["rand"].(func() int64)())
+	assert.Len(t, got, 3)
+
+	crypto := New(WithCryptoMap())
+	got = New(WithCryptoMap(), WithSeed(1), WithClock(clock.Playground.MustTime()))
+	delete(got, "now")
+	assert.Len(t, got, len(crypto))
+	for k, f := range crypto {
+		assert.True(t, sameFunc(f, got[k]), k)
+	}
+}
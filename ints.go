@@ -0,0 +1,140 @@
+package funcmap
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Like IntParser but for `bits` sized integers. With a `base` of 2, 8 or 16, an optional `0b`, `0o`
+// or `0x` prefix is allowed; with a `base` of 0, the prefix determines the base.
+func SizedIntParser(base, bits int) func(s string) (int64, error) {
+	return func(s string) (int64, error) {
+		return strconv.ParseInt(trimBasePrefix(base, s), base, bits)
+	}
+}
+
+// Like SizedIntParser but for unsigned integers.
+func UintParser(base, bits int) func(s string) (uint64, error) {
+	return func(s string) (uint64, error) {
+		return strconv.ParseUint(trimBasePrefix(base, s), base, bits)
+	}
+}
+
+// Parse a 64-bit integer in `base`. See SizedIntParser.
+func ParseInt(base int, s string) (int64, error) {
+	return SizedIntParser(base, 64)(s)
+}
+
+// Parse a 64-bit unsigned integer in `base`. See SizedIntParser.
+func ParseUint(base int, s string) (uint64, error) {
+	return UintParser(base, 64)(s)
+}
+
+var (
+	minInt128  = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+	maxInt128  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	maxUint128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+)
+
+// Parse a 128-bit integer in `base`. See SizedIntParser.
+func ParseInt128(base int, s string) (*big.Int, error) {
+	return parseBig(base, s, minInt128, maxInt128)
+}
+
+// Parse a 128-bit unsigned integer in `base`. See SizedIntParser.
+func ParseUint128(base int, s string) (*big.Int, error) {
+	return parseBig(base, s, new(big.Int), maxUint128)
+}
+
+func parseBig(base int, s string, min, max *big.Int) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(trimBasePrefix(base, s), base)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a base %d integer", s, base)
+	}
+	if i.Cmp(min) < 0 || i.Cmp(max) > 0 {
+		return nil, fmt.Errorf("%q is out of range", s)
+	}
+	return i, nil
+}
+
+// Like ToInt but an error, naming the elements that failed, instead of zeros.
+func ToIntStrict(base int, arr []string) ([]int64, error) {
+	return parseAll(arr, SizedIntParser(base, 64), fmt.Sprintf("base %d integers", base))
+}
+
+// Parse each of `arr` as ParseUint does, or an error naming the elements that failed.
+func ParseUints(base int, arr []string) ([]uint64, error) {
+	return parseAll(arr, UintParser(base, 64), fmt.Sprintf("base %d unsigned integers", base))
+}
+
+// Parse each of `arr` as ParseInt128 does. See ParseUints.
+func ParseInt128s(base int, arr []string) ([]*big.Int, error) {
+	parse := func(s string) (*big.Int, error) { return ParseInt128(base, s) }
+	return parseAll(arr, parse, fmt.Sprintf("base %d 128-bit integers", base))
+}
+
+// Parse each of `arr` as ParseUint128 does. See ParseUints.
+func ParseUint128s(base int, arr []string) ([]*big.Int, error) {
+	parse := func(s string) (*big.Int, error) { return ParseUint128(base, s) }
+	return parseAll(arr, parse, fmt.Sprintf("base %d 128-bit unsigned integers", base))
+}
+
+// Each element of `arr` parsed by `parse`, or an error naming those that are not `what`.
+func parseAll[T any](arr []string, parse func(string) (T, error), what string) ([]T, error) {
+	values := make([]T, len(arr))
+	failed := []string{}
+	for i, m := range arr {
+		v, err := parse(m)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%d:%q", i, m))
+			continue
+		}
+		values[i] = v
+	}
+	if len(failed) != 0 {
+		return nil, fmt.Errorf("not %s: %s", what, strings.Join(failed, ", "))
+	}
+	return values, nil
+}
+
+// The indexes of the elements of `arr` that are not base `base` integers.
+func InvalidInts(base int, arr []string) []int {
+	invalid := []int{}
+	parser := SizedIntParser(base, 64)
+	for i, m := range arr {
+		if _, err := parser(m); err != nil {
+			invalid = append(invalid, i)
+		}
+	}
+	return invalid
+}
+
+// The inverse of ToInt.
+func FromIntBase(base int, arr []int64) ([]string, error) {
+	if base < 2 || base > 36 {
+		return nil, fmt.Errorf("base %d is not between 2 and 36", base)
+	}
+	ss := make([]string, len(arr))
+	for i, m := range arr {
+		ss[i] = strconv.FormatInt(m, base)
+	}
+	return ss, nil
+}
+
+// Remove the `0b`, `0o` or `0x` prefix, after any sign, that matches `base`.
+func trimBasePrefix(base int, s string) string {
+	prefix := map[int]string{2: "0b", 8: "0o", 16: "0x"}[base]
+	if prefix == "" {
+		return s
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	if len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		s = s[len(prefix):]
+	}
+	return sign + s
+}
@@ -0,0 +1,127 @@
+package funcmap
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestSizedIntParser(t *testing.T) {
+	tests := []struct {
+		base, bits int
+		s          string
+		want       int64
+		wantErr    bool
+	}{
+		{base: 16, bits: 64, s: "0xff", want: 255},
+		{base: 16, bits: 64, s: "-0XFF", want: -255},
+		{base: 16, bits: 64, s: "0b12", want: 0xb12},
+		{base: 2, bits: 64, s: "0b101", want: 5},
+		{base: 8, bits: 64, s: "0o17", want: 15},
+		{base: 0, bits: 64, s: "0x1f", want: 31},
+		{base: 0, bits: 64, s: "0b11", want: 3},
+		{base: 10, bits: 8, s: "127", want: 127},
+		{base: 10, bits: 8, s: "128", wantErr: true},
+		{base: 10, bits: 64, s: "0x10", wantErr: true},
+		{base: 16, bits: 64, s: "0x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := SizedIntParser(tt.base, tt.bits)(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SizedIntParser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("SizedIntParser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestParseUint(t *testing.T) {
+	got, err := ParseUint(16, "0xffffffffffffffff")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1<<64-1), got)
+	_, err = ParseUint(10, "-1")
+	assert.Error(t, err)
+	_, err = UintParser(10, 16)("65536")
+	assert.Error(t, err)
+}
+
+//
+func TestParse128(t *testing.T) {
+	got, err := ParseUint128(16, "0xffffffffffffffffffffffffffffffff")
+	assert.NoError(t, err)
+	assert.Equal(t, maxUint128, got)
+	_, err = ParseUint128(16, "100000000000000000000000000000000")
+	assert.Error(t, err)
+	_, err = ParseUint128(10, "-1")
+	assert.Error(t, err)
+
+	got, err = ParseInt128(10, "-170141183460469231731687303715884105728")
+	assert.NoError(t, err)
+	assert.Equal(t, minInt128, got)
+	_, err = ParseInt128(10, "170141183460469231731687303715884105728")
+	assert.Error(t, err)
+	_, err = ParseInt128(10, "1e3")
+	assert.Error(t, err)
+
+	s, err := ToBase(16, got)
+	assert.NoError(t, err)
+	assert.Equal(t, "-80000000000000000000000000000000", s)
+	s, err = ToBase(2, uint64(1<<63))
+	assert.NoError(t, err)
+	assert.Equal(t, "1"+strings.Repeat("0", 63), s)
+}
+
+//
+func TestToIntStrict(t *testing.T) {
+	got, err := ToIntStrict(16, []string{"0x10", "ff"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{16, 255}, got)
+
+	_, err = ToIntStrict(10, []string{"1", "x", "2", ""})
+	assert.EqualError(t, err, `not base 10 integers: 1:"x", 3:""`)
+
+	assert.Equal(t, []int{1, 3}, InvalidInts(10, []string{"1", "x", "2", ""}))
+	assert.Equal(t, []int{}, InvalidInts(10, []string{"1"}))
+}
+
+//
+func TestParseSlices(t *testing.T) {
+	uints, err := ParseUints(16, []string{"0x10", "ffffffffffffffff"})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{16, math.MaxUint64}, uints)
+	_, err = ParseUints(10, []string{"1", "-1"})
+	assert.EqualError(t, err, `not base 10 unsigned integers: 1:"-1"`)
+
+	ints, err := ParseInt128s(10, []string{"-1", "170141183460469231731687303715884105727"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[-1 170141183460469231731687303715884105727]", fmt.Sprint(ints))
+	_, err = ParseInt128s(10, []string{"170141183460469231731687303715884105728", "x"})
+	assert.EqualError(t, err, `not base 10 128-bit integers: 0:"170141183460469231731687303715884105728", 1:"x"`)
+
+	ints, err = ParseUint128s(16, []string{"ff"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[255]", fmt.Sprint(ints))
+	_, err = ParseUint128s(16, []string{"-1"})
+	assert.EqualError(t, err, `not base 16 128-bit unsigned integers: 0:"-1"`)
+}
+
+//
+func TestFromIntBase(t *testing.T) {
+	got, err := FromIntBase(16, []int64{255, -16})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ff", "-10"}, got)
+	back, err := ToIntStrict(16, got)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{255, -16}, back)
+	_, err = FromIntBase(1, nil)
+	assert.Error(t, err)
+}
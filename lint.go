@@ -0,0 +1,117 @@
+package funcmap
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"text/template"
+	"text/template/parse"
+)
+
+// A function call in a template that does not mean the same thing with another map.
+type Migration struct {
+	Location string
+	Name     string
+	Reason   string
+}
+
+//
+func (m Migration) String() string {
+	return fmt.Sprintf("%s: %s %s", m.Location, m.Name, m.Reason)
+}
+
+// Find the calls, in the template `text`, to functions that are missing from or differ in `to`
+// compared to `from`, e.g. `Lint(New(WithV3Map()), New(WithV4Map()), name, text)`. Functions are
+// compared by identity, so the maps should be built without middleware.
+func Lint(from, to template.FuncMap, name, text string) ([]Migration, error) {
+	tmpl, err := template.New(name).Funcs(from).Funcs(to).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	templates := tmpl.Templates()
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name() < templates[j].Name() })
+
+	migrations := []Migration{}
+	for _, t := range templates {
+		if t.Tree == nil {
+			continue
+		}
+		walk(t.Tree.Root, func(n parse.Node) {
+			ident, ok := n.(*parse.IdentifierNode)
+			if !ok {
+				return
+			}
+			reason := migration(from[ident.Ident], to[ident.Ident])
+			if reason == "" {
+				return
+			}
+			location, _ := t.Tree.ErrorContext(n)
+			migrations = append(migrations, Migration{Location: location, Name: ident.Ident, Reason: reason})
+		})
+	}
+	return migrations, nil
+}
+
+// Why calling `to` in place of `from` changes a template, or empty if it does not.
+func migration(from, to interface{}) string {
+	if from == nil {
+		return ""
+	}
+	if to == nil {
+		return "is not defined"
+	}
+	fv, tv := reflect.ValueOf(from), reflect.ValueOf(to)
+	if fv.Pointer() == tv.Pointer() {
+		return ""
+	}
+	if fv.Type() != tv.Type() {
+		return fmt.Sprintf("changed from %s to %s", fv.Type(), tv.Type())
+	}
+	if tv.Type().NumIn() < 2 {
+		return fmt.Sprintf("is a different %s", tv.Type())
+	}
+	return fmt.Sprintf("is a different %s; check the order of its arguments", tv.Type())
+}
+
+// Call `visit` on `n` and every node below it, in the order they appear.
+func walk(n parse.Node, visit func(parse.Node)) {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return
+	}
+	visit(n)
+	switch n := n.(type) {
+	case *parse.ListNode:
+		for _, c := range n.Nodes {
+			walk(c, visit)
+		}
+	case *parse.ActionNode:
+		walk(n.Pipe, visit)
+	case *parse.PipeNode:
+		for _, d := range n.Decl {
+			walk(d, visit)
+		}
+		for _, c := range n.Cmds {
+			walk(c, visit)
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			walk(a, visit)
+		}
+	case *parse.ChainNode:
+		walk(n.Node, visit)
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.TemplateNode:
+		walk(n.Pipe, visit)
+	}
+}
+
+func walkBranch(n *parse.BranchNode, visit func(parse.Node)) {
+	walk(n.Pipe, visit)
+	walk(n.List, visit)
+	walk(n.ElseList, visit)
+}
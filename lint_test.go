@@ -0,0 +1,68 @@
+package funcmap
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestV4Map(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(New(WithV4Map())).Parse(
+		`{{ .s | trim "_" | replace -1 "-" "." | trim_left "a" }} {{ sub 2 5 }} {{ "a/b.txt" | basename "txt" }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, map[string]interface{}{"s": "__a-b-c__"}))
+	assert.Equal(t, ".b.c 3 b", b.String())
+}
+
+//
+func TestLint(t *testing.T) {
+	text := `{{ define "x" }}{{ trim . "_" }}{{ end }}
+{{- range .items }}{{ upper . }}{{ replace . "a" "b" -1 }}{{ end }}
+{{ if trimLeft .s "x" | eq "" }}{{ template "x" .s }}{{ end }}`
+
+	got, err := Lint(New(WithV1Map()), New(WithV4Map()), "t", text)
+	assert.NoError(t, err)
+	names := []string{}
+	for _, m := range got {
+		names = append(names, m.Name)
+	}
+	assert.Equal(t, []string{"replace", "trimLeft", "trim"}, names)
+	assert.Equal(t, "t:1:19: trim is a different func(string, string) string; check the order of its arguments", got[2].String())
+	assert.Equal(t, "t:2:35: replace changed from func(string, string, string, int) string to func(int, string, string, string) string", got[0].String())
+
+	got, err = Lint(New(WithV3Map()), New(WithV4Map()), "t", `{{ sub 1 2 }}{{ upper "a" }}{{ camelcase "a_b" }}{{ add 1 2 }}`)
+	assert.NoError(t, err)
+	assert.Len(t, got, 3)
+	assert.Equal(t, "sub", got[0].Name)
+	assert.Equal(t, "changed from func(interface {}, interface {}) int64 to func(int64, int64) int64", got[0].Reason)
+	assert.Equal(t, "camelcase", got[1].Name)
+	assert.Equal(t, "is not defined", got[1].Reason)
+	assert.Equal(t, "add", got[2].Name)
+
+	got, err = Lint(New(WithV1Map()), New(WithV4Map()), "t", `{{ basename .path "txt" }}`)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "t:1:3: basename changed from func(string, ...string) string to func(string, string) string", got[0].String())
+	}
+
+	got, err = Lint(New(WithV4Map()), New(WithV4Map()), "t", text)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = Lint(New(WithV1Map()), New(WithV4Map()), "t", `{{ unknown }}`)
+	assert.Error(t, err)
+}
+
+//
+func TestSafeMap(t *testing.T) {
+	safe := New(WithSafeMap())
+	for _, name := range []string{"env", "envDefault", "command_line", "glob", "pause", "debug_toggle", "next", "keynext"} {
+		assert.NotContains(t, safe, name)
+	}
+	assert.Contains(t, safe, "upper")
+	assert.Contains(t, New(WithSafeMap(), WithSequencers()), "next")
+	assert.True(t, sameFunc(safe["trim"], ReTrim))
+}
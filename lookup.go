@@ -0,0 +1,206 @@
+package funcmap
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The value at `path` in `v`, or `def` if there is none or it is nil.
+//
+// A path is a sequence of map keys, struct fields or JSON tags, and list or string indexes,
+// e.g. `a.b[2].c`, `items[-1]`, or `labels["app.kubernetes.io/name"]`. Negative indexes count
+// from the end. Map keys of any type match by their `%v` format.
+func Get(path string, def, v interface{}) interface{} {
+	found, err := lookupPath(reflect.ValueOf(v), path)
+	if err != nil || !found.IsValid() {
+		return def
+	}
+	return found.Interface()
+}
+
+// The value at `path` in `v`, or an error if there is none. See Get.
+func GetStrict(path string, v interface{}) (interface{}, error) {
+	found, err := lookupPath(reflect.ValueOf(v), path)
+	if err != nil {
+		return nil, err
+	}
+	if !found.IsValid() {
+		return nil, nil
+	}
+	return found.Interface(), nil
+}
+
+// The value at `path`, false if there is none. See Get.
+func lookup(v reflect.Value, path string) (reflect.Value, bool) {
+	found, err := lookupPath(v, path)
+	return found, err == nil && found.IsValid()
+}
+
+func lookupPath(v reflect.Value, path string) (reflect.Value, error) {
+	keys, err := parsePath(path)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v = indirect(v)
+	for i, key := range keys {
+		if !v.IsValid() {
+			return v, fmt.Errorf("%s: nil at %s", path, joinPath(keys[:i]))
+		}
+		next, ok := step(v, key)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%s: no %s in %s", path, joinPath(keys[i:i+1]), v.Type())
+		}
+		v = indirect(next)
+	}
+	return v, nil
+}
+
+func step(v reflect.Value, key string) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Map:
+		k, ok := mapKey(v, key)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		found := v.MapIndex(k)
+		return found, found.IsValid()
+	case reflect.Struct:
+		return field(v, key)
+	case reflect.Slice, reflect.Array:
+		i, ok := index(key, v.Len())
+		if !ok {
+			return reflect.Value{}, false
+		}
+		return v.Index(i), true
+	case reflect.String:
+		s := v.String()
+		i, ok := index(key, utf8.RuneCountInString(s))
+		if !ok {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(string([]rune(s)[i])), true
+	}
+	return reflect.Value{}, false
+}
+
+func index(key string, n int) (int, bool) {
+	i, err := strconv.Atoi(key)
+	if err != nil {
+		return 0, false
+	}
+	if i < 0 {
+		i += n
+	}
+	return i, i >= 0 && i < n
+}
+
+// The exported field named `name`, or whose JSON tag names it, including promoted fields.
+func field(v reflect.Value, name string) (reflect.Value, bool) {
+	if f, ok := v.Type().FieldByName(name); ok && f.PkgPath == "" {
+		return v.FieldByIndex(f.Index), true
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == name && f.PkgPath == "" {
+			return v.Field(i), true
+		}
+		if f.Anonymous {
+			if embedded := indirect(v.Field(i)); embedded.Kind() == reflect.Struct {
+				if found, ok := field(embedded, name); ok {
+					return found, true
+				}
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func mapKey(m reflect.Value, key interface{}) (reflect.Value, bool) {
+	k := reflect.ValueOf(key)
+	t := m.Type().Key()
+	switch {
+	case !k.IsValid():
+		return k, false
+	case k.Type().AssignableTo(t):
+		return k, true
+	case k.Type().ConvertibleTo(t) && k.Kind() == t.Kind():
+		return k.Convert(t), true
+	}
+	if isNumber(k) && isNumber(reflect.Zero(t)) && equal(k, k.Convert(t)) {
+		return k.Convert(t), true
+	}
+	if k.Kind() == reflect.String {
+		s := k.String()
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if i, err := strconv.ParseInt(s, 10, t.Bits()); err == nil {
+				return reflect.ValueOf(i).Convert(t), true
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if i, err := strconv.ParseUint(s, 10, t.Bits()); err == nil {
+				return reflect.ValueOf(i).Convert(t), true
+			}
+		}
+	}
+	name := fmt.Sprint(key)
+	for _, mk := range m.MapKeys() {
+		if format(mk) == name {
+			return mk, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// Split `a.b[2]["c.d"]` into `a`, `b`, `2`, `c.d`. A leading dot is optional.
+func parsePath(path string) ([]string, error) {
+	keys := []string{}
+	s := strings.TrimPrefix(path, ".")
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			if s == "" || s[0] == '.' || s[0] == '[' {
+				return nil, fmt.Errorf("%s: empty key", path)
+			}
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if len(s) > 1 && (s[1] == '"' || s[1] == '\'') {
+				end = strings.IndexByte(s[2:], s[1])
+				if end < 0 || len(s) < end+4 || s[end+3] != ']' {
+					return nil, fmt.Errorf("%s: unterminated quoted key", path)
+				}
+				keys = append(keys, s[2:end+2])
+				s = s[end+4:]
+				continue
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("%s: unterminated index", path)
+			}
+			keys = append(keys, strings.TrimSpace(s[1:end]))
+			s = s[end+1:]
+			continue
+		}
+		end := strings.IndexAny(s, ".[")
+		if end < 0 {
+			end = len(s)
+		}
+		keys = append(keys, s[:end])
+		s = s[end:]
+	}
+	return keys, nil
+}
+
+func joinPath(keys []string) string {
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = strconv.Quote(k)
+	}
+	return "[" + strings.Join(s, "][") + "]"
+}
@@ -0,0 +1,101 @@
+package funcmap
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type lookupBase struct {
+	ID string `json:"id"`
+}
+
+type lookupItem struct {
+	lookupBase
+	Name   string            `json:"name,omitempty"`
+	Labels map[string]string `json:"labels"`
+	Ports  []int
+	Next   *lookupItem
+	hidden string
+}
+
+var lookupData = map[string]interface{}{
+	"items": []lookupItem{
+		{lookupBase: lookupBase{ID: "1"}, Name: "a", Labels: map[string]string{"app.name": "x"}, Ports: []int{80, 443}},
+		{Name: "b", Next: &lookupItem{Name: "c"}, hidden: "h"},
+	},
+	"byNumber": map[int]string{1: "one", -2: "minus two"},
+	"byBool":   map[bool]string{true: "yes"},
+	"nil":      nil,
+	"text":     "héllo",
+}
+
+//
+func TestGetStrict(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantErr bool
+	}{
+		{path: "", want: lookupData},
+		{path: "items[0].Name", want: "a"},
+		{path: ".items.0.name", want: "a"},
+		{path: "items[-1].Next.Name", want: "c"},
+		{path: "items[0].Ports[-1]", want: 443},
+		{path: `items[0].labels["app.name"]`, want: "x"},
+		{path: `items[0].Labels['app.name']`, want: "x"},
+		{path: "items[0].ID", want: "1"},
+		{path: "items[0].id", want: "1"},
+		{path: "byNumber[1]", want: "one"},
+		{path: "byNumber.-2", want: "minus two"},
+		{path: "byBool.true", want: "yes"},
+		{path: "text[1]", want: "é"},
+		{path: "text[-1]", want: "o"},
+		{path: "nil", want: nil},
+		{path: "items[2]", wantErr: true},
+		{path: "items[-3]", wantErr: true},
+		{path: "items[0].hidden", wantErr: true},
+		{path: "items[0].Next.Name", wantErr: true},
+		{path: "nil.x", wantErr: true},
+		{path: "missing", wantErr: true},
+		{path: "items[0", wantErr: true},
+		{path: `items["0]`, wantErr: true},
+		{path: "items..Name", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := GetStrict(tt.path, lookupData)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetStrict() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+//
+func TestGet(t *testing.T) {
+	assert.Equal(t, "a", Get("items[0].name", "default", lookupData))
+	assert.Equal(t, "default", Get("items[9].name", "default", lookupData))
+	assert.Equal(t, "default", Get("nil", "default", lookupData))
+	assert.Equal(t, "default", Get("x", "default", nil))
+	assert.Equal(t, 80, Get("[0]", nil, &[]int{80}))
+
+	_, err := GetStrict("items[5]", lookupData)
+	assert.EqualError(t, err, `items[5]: no ["5"] in []funcmap.lookupItem`)
+}
+
+//
+func TestGetTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map())).Parse(
+		`{{ get "items[-1].Next.name" "" . }} {{ get "items[0].Ports[5]" 8080 . }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, lookupData))
+	assert.Equal(t, "c 8080", b.String())
+
+	tmpl = template.Must(template.New("").Funcs(New(WithV1Map())).Parse(`{{ get_strict "items[9]" . }}`))
+	assert.Error(t, tmpl.Execute(&b, lookupData))
+}
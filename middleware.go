@@ -0,0 +1,46 @@
+package funcmap
+
+import (
+	"reflect"
+)
+
+// Wraps the function registered as `name`. The returned function replaces `next` in the map and
+// should have the same type.
+type Middleware func(name string, next reflect.Value) reflect.Value
+
+// A Middleware that calls `around` in place of each function. `around` receives the arguments and
+// `call`, which invokes the wrapped function with them.
+func Around(around func(name string, in []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value) Middleware {
+	return func(name string, next reflect.Value) reflect.Value {
+		return reflect.MakeFunc(next.Type(), func(in []reflect.Value) []reflect.Value {
+			return around(name, in, func(in []reflect.Value) []reflect.Value {
+				return call(next, in)
+			})
+		})
+	}
+}
+
+// Apply `middleware` to `f`, the first being outermost. Values of `f` that are not functions, and
+// results of a Middleware that are not functions, are ignored.
+func Wrap(name string, f interface{}, middleware ...Middleware) interface{} {
+	fv := reflect.ValueOf(f)
+	if fv.Kind() != reflect.Func {
+		return f
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] == nil {
+			continue
+		}
+		if next := middleware[i](name, fv); next.IsValid() && next.Kind() == reflect.Func {
+			fv = next
+		}
+	}
+	return fv.Interface()
+}
+
+func call(fv reflect.Value, in []reflect.Value) []reflect.Value {
+	if fv.Type().IsVariadic() {
+		return fv.CallSlice(in)
+	}
+	return fv.Call(in)
+}
@@ -0,0 +1,60 @@
+package funcmap
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestWrap(t *testing.T) {
+	order := []string{}
+	mark := func(label string) Middleware {
+		return Around(func(name string, in []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value {
+			order = append(order, label+">"+name)
+			out := call(in)
+			order = append(order, label+"<"+name)
+			return out
+		})
+	}
+
+	add := Wrap("add", Add, mark("a"), nil, mark("b")).(func(int64, int64) int64)
+	assert.Equal(t, int64(3), add(1, 2))
+	assert.Equal(t, []string{"a>add", "b>add", "b<add", "a<add"}, order)
+
+	join := Wrap("path_join", PathJoin, mark("a")).(func(...string) string)
+	assert.Equal(t, "a/b", join("a", "b"))
+
+	assert.Equal(t, "x", Wrap("x", "x", mark("a")))
+
+	invalid := func(string, reflect.Value) reflect.Value { return reflect.Value{} }
+	assert.Equal(t, int64(5), Wrap("add", Add, invalid).(func(int64, int64) int64)(2, 3))
+}
+
+//
+func TestWithMiddleware(t *testing.T) {
+	counts := map[string]int{}
+	counting := func(name string, next reflect.Value) reflect.Value {
+		return reflect.MakeFunc(next.Type(), func(in []reflect.Value) []reflect.Value {
+			counts[name]++
+			return next.Call(in)
+		})
+	}
+	upper := Around(func(name string, in []reflect.Value, call func([]reflect.Value) []reflect.Value) []reflect.Value {
+		out := call(in)
+		if s, ok := out[0].Interface().(string); ok && name == "lower" {
+			out[0] = reflect.ValueOf(s + "!")
+		}
+		return out
+	})
+
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map(), WithMiddleware(counting, upper))).Parse(
+		`{{ add 1 2 }} {{ add 3 4 }} {{ lower "X" }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, nil))
+	assert.Equal(t, "3 7 x!", b.String())
+	assert.Equal(t, map[string]int{"add": 2, "lower": 1}, counts)
+}
@@ -0,0 +1,293 @@
+package funcmap
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Zero-pad `n` to `width` digits and group thousands with `sep`, if not empty.
+func FormatInt(width int, sep string, n interface{}) (string, error) {
+	i, err := toInt64(n)
+	if err != nil {
+		return "", err
+	}
+	sign, digits := "", strconv.FormatInt(i, 10)
+	if i < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) < width {
+		digits = strings.Repeat("0", width-len(digits)) + digits
+	}
+	return sign + group(digits, sep), nil
+}
+
+// Format `n` with `places` decimals, grouping thousands with `thousands` and separating the
+// decimals with `decimal`, e.g. `format_number 2 "." "," 1234.5` is `1.234,50`.
+func FormatNumber(places int, thousands, decimal string, n interface{}) (string, error) {
+	f, err := toFloat64(n)
+	if err != nil {
+		return "", err
+	}
+	if places < 0 {
+		places = 0
+	}
+	s := strconv.FormatFloat(math.Abs(f), 'f', places, 64)
+	whole, fraction := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		whole, fraction = s[:dot], decimal+s[dot+1:]
+	}
+	sign := ""
+	if f < 0 && strings.Trim(s, "0.") != "" {
+		sign = "-"
+	}
+	return sign + group(whole, thousands) + fraction, nil
+}
+
+// Format `n`, including unsigned and big integers, in `base`, from 2 to 36.
+func ToBase(base int, n interface{}) (string, error) {
+	if base < 2 || base > 36 {
+		return "", fmt.Errorf("base %d is not between 2 and 36", base)
+	}
+	if b, ok := n.(*big.Int); ok && b != nil {
+		return b.Text(base), nil
+	}
+	switch v := indirect(reflect.ValueOf(n)); v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), base), nil
+	}
+	i, err := toInt64(n)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(i, base), nil
+}
+
+//
+func ToBinary(n interface{}) (string, error) { return ToBase(2, n) }
+
+//
+func ToOctal(n interface{}) (string, error) { return ToBase(8, n) }
+
+//
+func ToHex(n interface{}) (string, error) { return ToBase(16, n) }
+
+//
+func ToBase36(n interface{}) (string, error) { return ToBase(36, n) }
+
+// A size in bytes using binary units, e.g. `1.5 KiB`.
+func HumanBytes(n interface{}) (string, error) {
+	return humanBytes(n, 1024, []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"})
+}
+
+// A size in bytes using decimal units, e.g. `1.5 kB`.
+func HumanBytesSI(n interface{}) (string, error) {
+	return humanBytes(n, 1000, []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"})
+}
+
+func humanBytes(n interface{}, unit float64, units []string) (string, error) {
+	f, err := toFloat64(n)
+	if err != nil {
+		return "", err
+	}
+	i := 0
+	for i < len(units)-1 && (math.Abs(f) >= unit || i > 0 && math.Abs(math.Round(f*10)/10) >= unit) {
+		f /= unit
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d %s", int64(f), units[i]), nil
+	}
+	return strings.Replace(fmt.Sprintf("%.1f %s", f, units[i]), ".0 ", " ", 1), nil
+}
+
+// A time.Duration from a duration, a string such as `1h30m`, or a number of seconds.
+func Duration(d interface{}) (time.Duration, error) {
+	switch d := d.(type) {
+	case time.Duration:
+		return d, nil
+	case string:
+		if parsed, err := time.ParseDuration(d); err == nil {
+			return parsed, nil
+		}
+	}
+	f, err := toFloat64(d)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
+
+// The two largest units of a duration, e.g. `2d3h`, `1m30s`, or `250ms`. See Duration.
+func HumanDuration(d interface{}) (string, error) {
+	duration, err := Duration(d)
+	if err != nil {
+		return "", err
+	}
+	sign := ""
+	if duration < 0 {
+		sign, duration = "-", -duration
+	}
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"µs", time.Microsecond},
+		{"ns", time.Nanosecond},
+	}
+	for i, u := range units {
+		if duration < u.size {
+			continue
+		}
+		s := fmt.Sprintf("%d%s", duration/u.size, u.name)
+		if i+1 < len(units) {
+			if n := duration % u.size / units[i+1].size; n > 0 {
+				s += fmt.Sprintf("%d%s", n, units[i+1].name)
+			}
+		}
+		return sign + s, nil
+	}
+	return "0s", nil
+}
+
+// A ratio as a percentage with `places` decimals, e.g. `percent 1 0.125` is `12.5%`.
+func Percent(places int, ratio interface{}) (string, error) {
+	f, err := toFloat64(ratio)
+	if err != nil {
+		return "", err
+	}
+	if places < 0 {
+		places = 0
+	}
+	return strconv.FormatFloat(f*100, 'f', places, 64) + "%", nil
+}
+
+// Round `n` half away from zero to `places` decimals. Negative places round to tens, hundreds, etc.
+func RoundTo(places int, n interface{}) (float64, error) {
+	f, err := toFloat64(n)
+	if err != nil {
+		return 0, err
+	}
+	scale := math.Pow(10, float64(places))
+	return math.Round(f*scale) / scale, nil
+}
+
+//
+func Ceil(n interface{}) (float64, error) {
+	f, err := toFloat64(n)
+	return math.Ceil(f), err
+}
+
+//
+func Floor(n interface{}) (float64, error) {
+	f, err := toFloat64(n)
+	return math.Floor(f), err
+}
+
+// Roman numerals for 1 through 3999.
+func Roman(n interface{}) (string, error) {
+	i, err := toInt64(n)
+	if err != nil {
+		return "", err
+	}
+	if i < 1 || i > 3999 {
+		return "", fmt.Errorf("%d cannot be written in Roman numerals", i)
+	}
+	numerals := []struct {
+		value  int64
+		symbol string
+	}{
+		{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+		{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+		{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+	}
+	b := strings.Builder{}
+	for _, r := range numerals {
+		for ; i >= r.value; i -= r.value {
+			b.WriteString(r.symbol)
+		}
+	}
+	return b.String(), nil
+}
+
+// Insert `sep` between groups of three digits.
+func group(digits, sep string) string {
+	if sep == "" || len(digits) <= 3 {
+		return digits
+	}
+	b := strings.Builder{}
+	for i, d := range digits {
+		if i != 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// Any integer, integral float, or string of either.
+func toInt64(n interface{}) (int64, error) {
+	v := indirect(reflect.ValueOf(n))
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%v overflows int64", n)
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an int64", n)
+		}
+		return int64(f), nil
+	case reflect.String:
+		s := strings.TrimSpace(v.String())
+		if i, err := parseInt64(s); err == nil {
+			return i, nil
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return toInt64(f)
+		}
+	}
+	return 0, fmt.Errorf("%v (%T) is not an integer", n, n)
+}
+
+// A decimal integer, or one with a `0x`, `0b` or `0o` prefix. Unlike base 0 of strconv.ParseInt,
+// a leading zero, as in `010`, is not octal.
+func parseInt64(s string) (int64, error) {
+	for _, base := range []int{16, 2, 8} {
+		if t := trimBasePrefix(base, s); t != s {
+			return strconv.ParseInt(t, base, 64)
+		}
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// Any number or string of one.
+func toFloat64(n interface{}) (float64, error) {
+	v := indirect(reflect.ValueOf(n))
+	switch {
+	case isNumber(v):
+		return float(v), nil
+	case v.Kind() == reflect.String:
+		s := strings.TrimSpace(v.String())
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, nil
+		}
+		if i, err := parseInt64(s); err == nil {
+			return float64(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%v (%T) is not a number", n, n)
+}
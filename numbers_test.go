@@ -0,0 +1,192 @@
+package funcmap
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestFormatInt(t *testing.T) {
+	type args struct {
+		width int
+		sep   string
+		n     interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", args: args{0, "", 1234567}, want: "1234567"},
+		{name: "grouped", args: args{0, ",", 1234567}, want: "1,234,567"},
+		{name: "short", args: args{0, ",", 123}, want: "123"},
+		{name: "padded", args: args{5, "", 42}, want: "00042"},
+		{name: "padded grouped", args: args{6, " ", int64(-1234)}, want: "-001 234"},
+		{name: "uint", args: args{0, ",", uint32(1000)}, want: "1,000"},
+		{name: "float", args: args{0, ",", 1e6}, want: "1,000,000"},
+		{name: "string", args: args{0, ",", "0x10"}, want: "16"},
+		{name: "zero padded", args: args{0, ",", "010"}, want: "10"},
+		{name: "zero padded 8", args: args{0, ",", "08"}, want: "8"},
+		{name: "binary", args: args{0, ",", "-0b101"}, want: "-5"},
+		{name: "octal", args: args{0, ",", "0o10"}, want: "8"},
+		{name: "fraction", args: args{0, ",", 1.5}, wantErr: true},
+		{name: "text", args: args{0, ",", "ten"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatInt(tt.args.width, tt.args.sep, tt.args.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FormatInt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FormatInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestFormatNumber(t *testing.T) {
+	type args struct {
+		places    int
+		thousands string
+		decimal   string
+		n         interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "en", args: args{2, ",", ".", 1234567.891}, want: "1,234,567.89"},
+		{name: "de", args: args{2, ".", ",", 1234.5}, want: "1.234,50"},
+		{name: "whole", args: args{0, ",", ".", 999.5}, want: "1,000"},
+		{name: "negative", args: args{1, ",", ".", -1234.56}, want: "-1,234.6"},
+		{name: "negative zero", args: args{1, ",", ".", -0.01}, want: "0.0"},
+		{name: "string", args: args{1, "", ".", "12.34"}, want: "12.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatNumber(tt.args.places, tt.args.thousands, tt.args.decimal, tt.args.n)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+//
+func TestToBase(t *testing.T) {
+	got, _ := ToBinary(5)
+	assert.Equal(t, "101", got)
+	got, _ = ToOctal(8)
+	assert.Equal(t, "10", got)
+	got, _ = ToHex(255)
+	assert.Equal(t, "ff", got)
+	got, _ = ToBase36(int64(35))
+	assert.Equal(t, "z", got)
+	got, _ = ToBase(3, "-9")
+	assert.Equal(t, "-100", got)
+	got, _ = ToHex("010")
+	assert.Equal(t, "a", got)
+	_, err := ToBase(37, 1)
+	assert.Error(t, err)
+}
+
+//
+func TestHumanBytes(t *testing.T) {
+	tests := []struct {
+		n   interface{}
+		iec string
+		si  string
+	}{
+		{0, "0 B", "0 B"},
+		{999, "999 B", "999 B"},
+		{1024, "1 KiB", "1 kB"},
+		{1536, "1.5 KiB", "1.5 kB"},
+		{int64(5 << 30), "5 GiB", "5.4 GB"},
+		{"1000000", "976.6 KiB", "1 MB"},
+		{1048575, "1 MiB", "1 MB"},
+		{999999, "976.6 KiB", "1 MB"},
+	}
+	for _, tt := range tests {
+		iec, err := HumanBytes(tt.n)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.iec, iec)
+		si, err := HumanBytesSI(tt.n)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.si, si)
+	}
+}
+
+//
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		d    interface{}
+		want string
+	}{
+		{0, "0s"},
+		{90, "1m30s"},
+		{"26h", "1d2h"},
+		{"1h0m30s", "1h"},
+		{250 * time.Millisecond, "250ms"},
+		{-3600.5, "-1h"},
+		{"1.5", "1s500ms"},
+	}
+	for _, tt := range tests {
+		got, err := HumanDuration(tt.d)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%v", tt.d)
+	}
+	_, err := HumanDuration("soon")
+	assert.Error(t, err)
+}
+
+//
+func TestRounding(t *testing.T) {
+	got, _ := Percent(1, 0.125)
+	assert.Equal(t, "12.5%", got)
+	got, _ = Percent(0, "1")
+	assert.Equal(t, "100%", got)
+
+	f, _ := RoundTo(2, 1.005001)
+	assert.Equal(t, 1.01, f)
+	f, _ = RoundTo(0, -2.5)
+	assert.Equal(t, -3.0, f)
+	f, _ = RoundTo(-2, 1250)
+	assert.Equal(t, 1300.0, f)
+	f, _ = Ceil("1.2")
+	assert.Equal(t, 2.0, f)
+	f, _ = Floor(-1.2)
+	assert.Equal(t, -2.0, f)
+	_, err := Floor("x")
+	assert.Error(t, err)
+}
+
+//
+func TestRoman(t *testing.T) {
+	tests := map[interface{}]string{1: "I", 4: "IV", 9: "IX", 14: "XIV", 1994: "MCMXCIV", "2024": "MMXXIV", "010": "X", 3999: "MMMCMXCIX"}
+	for n, want := range tests {
+		got, err := Roman(n)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := Roman(0)
+	assert.Error(t, err)
+	_, err = Roman(4000)
+	assert.Error(t, err)
+}
+
+//
+func TestNumbersTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map())).Parse(
+		`{{ format_int 0 "," .size }} {{ human_bytes .size }} {{ .ratio | percent 0 }} v{{ roman 3 }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, map[string]interface{}{"size": 2500000, "ratio": "0.5"}))
+	assert.Equal(t, "2,500,000 2.4 MiB 50% vIII", b.String())
+}
@@ -0,0 +1,155 @@
+package funcmap
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//
+func PathJoin(elem ...string) string {
+	return filepath.Join(elem...)
+}
+
+// The path of `target` relative to `base`.
+func Rel(base, target string) (string, error) {
+	return filepath.Rel(base, target)
+}
+
+//
+func Match(pattern, name string) (bool, error) {
+	return filepath.Match(pattern, name)
+}
+
+// Replace every backslash with a slash, regardless of the OS.
+func ToSlash(path string) string {
+	return strings.Replace(path, `\`, "/", -1)
+}
+
+// Replace every slash with a backslash, regardless of the OS.
+func ToBackslash(path string) string {
+	return strings.Replace(path, "/", `\`, -1)
+}
+
+// Filesystem functions restricted to `root`. Names are slash-separated and relative to `root`;
+// absolute names and names that resolve outside of `root`, including through symlinks, are errors.
+func Rooted(root string) template.FuncMap {
+	r := rooted{root: filepath.Clean(root)}
+	if abs, err := filepath.Abs(r.root); err == nil {
+		r.root = abs
+	}
+	r.real = r.root
+	if real, err := filepath.EvalSymlinks(r.root); err == nil {
+		r.real = real
+	}
+	return template.FuncMap{
+		"read_file":   r.readFile,
+		"readFile":    r.readFile,
+		"file_exists": r.fileExists,
+		"fileExists":  r.fileExists,
+		"list_dir":    r.listDir,
+		"listDir":     r.listDir,
+		"glob":        r.glob,
+	}
+}
+
+type rooted struct {
+	root, real string
+}
+
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func (r rooted) path(name string) (string, error) {
+	name = filepath.FromSlash(name)
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("%s: absolute paths are not allowed", name)
+	}
+	p := filepath.Join(r.root, name)
+	if !within(r.root, p) {
+		return "", fmt.Errorf("%s: outside of %s", name, r.root)
+	}
+	if real, err := filepath.EvalSymlinks(p); err == nil && !within(r.real, real) {
+		return "", fmt.Errorf("%s: outside of %s", name, r.root)
+	}
+	return p, nil
+}
+
+//
+func (r rooted) readFile(name string) (string, error) {
+	p, err := r.path(name)
+	if err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+//
+func (r rooted) fileExists(name string) (bool, error) {
+	p, err := r.path(name)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Sorted names of the entries in the directory.
+func (r rooted) listDir(name string) ([]string, error) {
+	p, err := r.path(name)
+	if err != nil {
+		return nil, err
+	}
+	fis, err := ioutil.ReadDir(p)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(fis))
+	for i, fi := range fis {
+		names[i] = fi.Name()
+	}
+	return names, nil
+}
+
+// Sorted, slash-separated matches relative to the root.
+func (r rooted) glob(pattern string) ([]string, error) {
+	p, err := r.path(pattern)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := filepath.Glob(p)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, m := range matches {
+		rel, err := filepath.Rel(r.root, m)
+		if err != nil {
+			continue
+		}
+		if _, err := r.path(rel); err != nil {
+			continue
+		}
+		names = append(names, filepath.ToSlash(rel))
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,143 @@
+package funcmap
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestRel(t *testing.T) {
+	type args struct {
+		base   string
+		target string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "child",
+			args: args{base: "/a/b", target: "/a/b/c/d"},
+			want: "c/d",
+		},
+		{
+			name: "sibling",
+			args: args{base: "/a/b", target: "/a/c"},
+			want: "../c",
+		},
+		{
+			name:    "mixed",
+			args:    args{base: "/a", target: "b"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Rel(tt.args.base, tt.args.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Rel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestSlashes(t *testing.T) {
+	assert.Equal(t, "a/b/c", ToSlash(`a\b/c`))
+	assert.Equal(t, `a\b\c`, ToBackslash(`a/b\c`))
+	assert.Equal(t, "a/b/c", PathJoin("a", "b/", "c"))
+}
+
+//
+func TestMatch(t *testing.T) {
+	ok, err := Match("*.go", "funcs.go")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	_, err = Match("[", "funcs.go")
+	assert.Error(t, err)
+}
+
+//
+func TestRooted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "funcmap")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	root := filepath.Join(dir, "root")
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "a.txt"), []byte("A"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("B"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "secret"), []byte("S"), 0644))
+	assert.NoError(t, os.Symlink(filepath.Join(dir, "secret"), filepath.Join(root, "link")))
+
+	fm := Rooted(root)
+	readFile := fm["read_file"].(func(string) (string, error))
+	fileExists := fm["file_exists"].(func(string) (bool, error))
+	listDir := fm["list_dir"].(func(string) ([]string, error))
+	glob := fm["glob"].(func(string) ([]string, error))
+
+	s, err := readFile("sub/b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "B", s)
+
+	for _, name := range []string{"../secret", "sub/../../secret", "link", filepath.Join(dir, "secret")} {
+		_, err := readFile(name)
+		assert.Error(t, err, name)
+	}
+
+	ok, err := fileExists("a.txt")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = fileExists("missing")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	_, err = fileExists("../secret")
+	assert.Error(t, err)
+
+	names, err := listDir(".")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "link", "sub"}, names)
+	_, err = listDir("..")
+	assert.Error(t, err)
+
+	names, err = glob("*/*.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sub/b.txt"}, names)
+	names, err = glob("*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "sub"}, names)
+	_, err = glob("../*")
+	assert.Error(t, err)
+}
+
+//
+func TestWithRoot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "funcmap")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "snippet"), []byte("included"), 0644))
+
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map(), WithRoot(dir))).Parse(
+		`{{ if file_exists "snippet" }}{{ read_file "snippet" }}{{ end }} {{ glob "*" }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, nil))
+	assert.Equal(t, "included [snippet]", b.String())
+
+	assert.NotContains(t, New(WithV1Map()), "read_file")
+	for _, name := range []string{"glob", "abs"} {
+		assert.Contains(t, New(WithV1Map()), name)
+		assert.NotContains(t, New(WithSafeMap()), name)
+	}
+}
@@ -0,0 +1,55 @@
+package funcmap
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A panic recovered from the function registered as `Name`.
+type PanicError struct {
+	Name  string
+	Args  []interface{}
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s(%s): panic: %v", e.Name, Debug(e.Args...), e.Value)
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// A Middleware that returns a *PanicError instead of panicking. Functions with a single result
+// gain an error result; functions that already return an error return it in place of the panic.
+func Recovering() Middleware {
+	return func(name string, next reflect.Value) reflect.Value {
+		t := next.Type()
+		n := t.NumOut()
+		errs := n == 2 && t.Out(1) == errorType
+		ft := t
+		if !errs {
+			if n != 1 {
+				return next
+			}
+			in := make([]reflect.Type, t.NumIn())
+			for i := range in {
+				in[i] = t.In(i)
+			}
+			ft = reflect.FuncOf(in, []reflect.Type{t.Out(0), errorType}, t.IsVariadic())
+		}
+		return reflect.MakeFunc(ft, func(in []reflect.Value) (out []reflect.Value) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+				var err error = &PanicError{Name: name, Args: interfaces(in), Value: r}
+				out = []reflect.Value{reflect.Zero(ft.Out(0)), reflect.ValueOf(&err).Elem()}
+			}()
+			out = call(next, in)
+			if !errs {
+				out = append(out, reflect.Zero(errorType))
+			}
+			return out
+		})
+	}
+}
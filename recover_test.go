@@ -0,0 +1,49 @@
+package funcmap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestRecovering(t *testing.T) {
+	div := Wrap("div_", Div, Recovering()).(func(int64, int64) (int64, error))
+	got, err := div(2, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+
+	_, err = div(0, 4)
+	pe := &PanicError{}
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, "div_", pe.Name)
+	assert.Equal(t, []interface{}{int64(0), int64(4)}, pe.Args)
+	assert.EqualError(t, err, "div_(int64 0 int64 4): panic: runtime error: integer divide by zero")
+
+	cleanser := Wrap("cleanser", Cleanser, Recovering()).(func(string, string) (string, error))
+	_, err = cleanser("[", "x")
+	assert.Error(t, err)
+
+	fromJSON := Wrap("from_json", FromJSON, Recovering()).(func(string) (interface{}, error))
+	_, err = fromJSON("{")
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &pe))
+
+	step := Wrap("inc", Step, Recovering()).(func(int64, ...int) (int64, error))
+	got, err = step(1, 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), got)
+}
+
+//
+func TestWithRecover(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map(), WithRecover())).Parse(`{{ add 1 2 }}{{ div_ 0 1 }}`))
+	b := bytes.Buffer{}
+	err := tmpl.Execute(&b, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "div_(int64 0 int64 1): panic: runtime error: integer divide by zero")
+	assert.Equal(t, "3", b.String())
+}
@@ -0,0 +1,169 @@
+package funcmap
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// The fields of a semantic version: `major`, `minor`, `patch`, `prerelease`, `metadata`, and
+// `version`, the canonical form without any `v` prefix.
+func SemverParse(v string) (map[string]interface{}, error) {
+	sv, err := parseSemver(v)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"major":      sv.Major(),
+		"minor":      sv.Minor(),
+		"patch":      sv.Patch(),
+		"prerelease": sv.Prerelease(),
+		"metadata":   sv.Metadata(),
+		"version":    sv.String(),
+	}, nil
+}
+
+// Increment the `major`, `minor`, `patch` or `prerelease` part of `v`, keeping any `v` prefix.
+// The `prerelease` of `1.2.3-rc.1` is `1.2.3-rc.2`, and of `1.2.3` is `1.2.4-0`.
+func SemverBump(part, v string) (string, error) {
+	sv, err := parseSemver(v)
+	if err != nil {
+		return "", err
+	}
+	var next semver.Version
+	switch strings.ToLower(part) {
+	case "major":
+		next = sv.IncMajor()
+	case "minor":
+		next = sv.IncMinor()
+	case "patch":
+		next = sv.IncPatch()
+	case "prerelease":
+		pre := sv.Prerelease()
+		if pre == "" {
+			next, pre = sv.IncPatch(), "0"
+		} else {
+			next, pre = *sv, bumpPrerelease(pre)
+		}
+		if next, err = next.SetMetadata(""); err == nil {
+			next, err = next.SetPrerelease(pre)
+		}
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("%q is not major, minor, patch or prerelease", part)
+	}
+	return next.Original(), nil
+}
+
+// Replace the prerelease of `v` with `pre`, which may be empty.
+func SemverPrerelease(pre, v string) (string, error) {
+	sv, err := parseSemver(v)
+	if err != nil {
+		return "", err
+	}
+	next, err := sv.SetPrerelease(pre)
+	if err != nil {
+		return "", fmt.Errorf("prerelease %q: %v", pre, err)
+	}
+	return next.Original(), nil
+}
+
+// -1, 0 or 1 as `b` is older than, the same as, or newer than `a`. Build metadata is ignored.
+func SemverCompare(a, b string) (int, error) {
+	va, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	return vb.Compare(va), nil
+}
+
+// Sort a list of versions, oldest first, keeping their original form.
+func SemverSort(list interface{}) ([]string, error) {
+	vs, err := parseSemvers(list)
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(semver.Collection(vs))
+	ss := make([]string, len(vs))
+	for i, v := range vs {
+		ss[i] = v.Original()
+	}
+	return ss, nil
+}
+
+// Whether `v` satisfies `constraint`, e.g. `>= 1.2, < 2.0.0` or `~1.4`.
+func SemverCheck(constraint, v string) (bool, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, fmt.Errorf("constraint %q: %v", constraint, err)
+	}
+	sv, err := parseSemver(v)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(sv), nil
+}
+
+// The newest version in the list that satisfies `constraint`, or empty if none do.
+func SemverMax(constraint string, list interface{}) (string, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("constraint %q: %v", constraint, err)
+	}
+	vs, err := parseSemvers(list)
+	if err != nil {
+		return "", err
+	}
+	var max *semver.Version
+	for _, v := range vs {
+		if c.Check(v) && (max == nil || v.GreaterThan(max)) {
+			max = v
+		}
+	}
+	if max == nil {
+		return "", nil
+	}
+	return max.Original(), nil
+}
+
+func parseSemver(v string) (*semver.Version, error) {
+	sv, err := semver.NewVersion(strings.TrimSpace(v))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a semantic version", v)
+	}
+	return sv, nil
+}
+
+func parseSemvers(list interface{}) ([]*semver.Version, error) {
+	is, err := items(list)
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]*semver.Version, len(is))
+	for i, v := range is {
+		if vs[i], err = parseSemver(fmt.Sprint(v)); err != nil {
+			return nil, err
+		}
+	}
+	return vs, nil
+}
+
+// Increment the last numeric identifier of a prerelease, or append `.1` if there is none.
+func bumpPrerelease(pre string) string {
+	ids := strings.Split(pre, ".")
+	last := len(ids) - 1
+	if n, err := strconv.ParseUint(ids[last], 10, 64); err == nil {
+		ids[last] = strconv.FormatUint(n+1, 10)
+		return strings.Join(ids, ".")
+	}
+	return pre + ".1"
+}
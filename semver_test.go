@@ -0,0 +1,122 @@
+package funcmap
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//
+func TestSemverParse(t *testing.T) {
+	got, err := SemverParse("v1.2.3-rc.1+build.5")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"major": int64(1), "minor": int64(2), "patch": int64(3),
+		"prerelease": "rc.1", "metadata": "build.5", "version": "1.2.3-rc.1+build.5",
+	}, got)
+	_, err = SemverParse("one")
+	assert.Error(t, err)
+}
+
+//
+func TestSemverBump(t *testing.T) {
+	tests := []struct {
+		part    string
+		v       string
+		want    string
+		wantErr bool
+	}{
+		{part: "major", v: "1.2.3", want: "2.0.0"},
+		{part: "minor", v: "v1.2.3+build", want: "v1.3.0"},
+		{part: "patch", v: "1.2.3", want: "1.2.4"},
+		{part: "patch", v: "1.2.3-rc.1", want: "1.2.3"},
+		{part: "prerelease", v: "1.2.3", want: "1.2.4-0"},
+		{part: "prerelease", v: "v1.2.3-rc.1+build", want: "v1.2.3-rc.2"},
+		{part: "prerelease", v: "1.2.3-beta", want: "1.2.3-beta.1"},
+		{part: "build", v: "1.2.3", wantErr: true},
+		{part: "major", v: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.part+" "+tt.v, func(t *testing.T) {
+			got, err := SemverBump(tt.part, tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SemverBump() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SemverBump() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//
+func TestSemverPrerelease(t *testing.T) {
+	got, err := SemverPrerelease("rc.1", "v2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.0.0-rc.1", got)
+	got, err = SemverPrerelease("", "2.0.0-rc.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", got)
+	_, err = SemverPrerelease("rc_1", "2.0.0")
+	assert.Error(t, err)
+}
+
+//
+func TestSemverCompare(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.4", 1},
+		{"1.2.4", "1.2.3", -1},
+		{"1.2.3", "v1.2.3+build", 0},
+		{"1.0.0", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.2", "1.0.0-rc.10", 1},
+	} {
+		got, err := SemverCompare(tt.a, tt.b)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%s %s", tt.a, tt.b)
+	}
+	_, err := SemverCompare("1", "x")
+	assert.Error(t, err)
+}
+
+//
+func TestSemverSort(t *testing.T) {
+	got, err := SemverSort([]interface{}{"v1.10.0", "1.2.0", "1.2.0-rc.1", "0.9"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0.9", "1.2.0-rc.1", "1.2.0", "v1.10.0"}, got)
+	_, err = SemverSort([]string{"1.0.0", "latest"})
+	assert.Error(t, err)
+}
+
+//
+func TestSemverConstraints(t *testing.T) {
+	ok, err := SemverCheck(">= 1.2, < 2.0.0", "1.4.0")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, _ = SemverCheck("~1.4", "1.5.0")
+	assert.False(t, ok)
+	_, err = SemverCheck("newest", "1.0.0")
+	assert.Error(t, err)
+
+	versions := []string{"1.2.0", "v1.9.1", "2.0.0", "1.10.0-rc.1"}
+	got, err := SemverMax("^1", versions)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.9.1", got)
+	got, err = SemverMax(">= 3", versions)
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+}
+
+//
+func TestSemverTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(New(WithV1Map())).Parse(
+		`{{ $v := semver_parse .version }}{{ $v.major }} {{ .version | semver_bump "minor" }} {{ semver_max "< 2.0.0" .released }}`))
+	b := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&b, map[string]interface{}{"version": "v1.4.2", "released": []interface{}{"1.0.0", "1.4.2", "2.1.0"}}))
+	assert.Equal(t, "1 v1.5.0 1.4.2", b.String())
+}